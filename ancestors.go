@@ -0,0 +1,64 @@
+package gophernews
+
+import (
+	"context"
+	"fmt"
+)
+
+// Follows Parent (or Poll, for the part of a poll) from any item up to the
+// story or poll it belongs to and returns the full path, starting with that
+// story or poll and ending with the item itself
+func (c *Client) GetAncestors(id int) ([]Item, error) {
+	return c.GetAncestorsContext(context.Background(), id)
+}
+
+// Same as GetAncestors, but the requests are bound to ctx
+func (c *Client) GetAncestorsContext(ctx context.Context, id int) ([]Item, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("gophernews: invalid item ID %d", id)
+	}
+
+	var path []Item
+	seen := make(map[int]bool)
+
+	for id != 0 {
+		if seen[id] {
+			return nil, fmt.Errorf("gophernews: item %d is its own ancestor", id)
+		}
+		seen[id] = true
+
+		i, err := c.GetItemContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		path = append(path, i)
+		id = i.Parent()
+		if id == 0 {
+			// The API links the parts of a poll to it through poll
+			id = i.Poll()
+		}
+	}
+
+	// Reverse the path so that it starts at the root
+	for l, r := 0, len(path)-1; l < r; l, r = l+1, r-1 {
+		path[l], path[r] = path[r], path[l]
+	}
+
+	return path, nil
+}
+
+// Returns the story or poll at the top of the thread an item belongs to.
+// For a story or poll, that is the item itself.
+func (c *Client) GetRootStory(id int) (Item, error) {
+	return c.GetRootStoryContext(context.Background(), id)
+}
+
+// Same as GetRootStory, but the requests are bound to ctx
+func (c *Client) GetRootStoryContext(ctx context.Context, id int) (Item, error) {
+	path, err := c.GetAncestorsContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return path[0], nil
+}
@@ -0,0 +1,105 @@
+package gophernews
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetAncestors(t *testing.T) {
+	setup()
+	defer teardown()
+	setupThread()
+
+	path, err := client.GetAncestors(105)
+	if err != nil {
+		t.Fatalf("Error for client.GetAncestors(105) should have been nil. Was: %v", err)
+	}
+
+	// Makes sure the path runs from the story down to the comment
+	var ids []int
+	for _, i := range path {
+		ids = append(ids, i.ID())
+	}
+	if fmt.Sprint(ids) != "[8863 101 103 105]" {
+		t.Errorf("client.GetAncestors(105) returned %v, was expecting [8863 101 103 105]", ids)
+	}
+
+	root, err := client.GetRootStory(105)
+	if err != nil || root.ID() != 8863 || root.Type() != "story" {
+		t.Errorf("client.GetRootStory(105) returned %v, %v, was expecting story 8863", root, err)
+	}
+
+	// A story is its own root
+	root, err = client.GetRootStory(8863)
+	if err != nil || root.ID() != 8863 {
+		t.Errorf("client.GetRootStory(8863) returned %v, %v, was expecting story 8863", root, err)
+	}
+}
+
+func TestGetAncestorsErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stubs for a comment whose parent is gone and two comments
+	// pointing at each other
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"parent":2,"type":"comment"}`)
+	})
+	mux.HandleFunc("/v0/item/2.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "null")
+	})
+	mux.HandleFunc("/v0/item/3.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3,"parent":4,"type":"comment"}`)
+	})
+	mux.HandleFunc("/v0/item/4.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":4,"parent":3,"type":"comment"}`)
+	})
+
+	if _, err := client.GetAncestors(1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("client.GetAncestors(1) returned %v, was expecting %v", err, ErrNotFound)
+	}
+
+	if _, err := client.GetRootStory(3); err == nil {
+		t.Errorf("Error for client.GetRootStory(3) should not have been nil for a cycle")
+	}
+}
+
+func TestGetAncestorsInvalidID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	for _, id := range []int{0, -1} {
+		if _, err := client.GetRootStory(id); err == nil {
+			t.Errorf("Error for client.GetRootStory(%d) should not have been nil", id)
+		}
+		if path, err := client.GetAncestors(id); err == nil || path != nil {
+			t.Errorf("client.GetAncestors(%d) returned %v, %v, was expecting an error", id, path, err)
+		}
+	}
+}
+
+func TestGetAncestorsPollopt(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stubs for a poll and a part linked to it the way the live
+	// API does, through poll rather than parent
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"parts":[2],"type":"poll"}`)
+	})
+	mux.HandleFunc("/v0/item/2.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2,"poll":1,"type":"pollopt"}`)
+	})
+
+	path, err := client.GetAncestors(2)
+	if err != nil || len(path) != 2 || path[0].ID() != 1 || path[1].ID() != 2 {
+		t.Errorf("client.GetAncestors(2) returned %v, %v, was expecting poll 1 then part 2", path, err)
+	}
+
+	root, err := client.GetRootStory(2)
+	if err != nil || root.ID() != 1 || root.Type() != "poll" {
+		t.Errorf("client.GetRootStory(2) returned %v, %v, was expecting poll 1", root, err)
+	}
+}
@@ -0,0 +1,82 @@
+package gophernews
+
+import (
+	"context"
+	"sync"
+)
+
+// The number of requests GetItems keeps in flight when not told otherwise
+const defaultConcurrency = 8
+
+// BatchOptions tunes GetItems. A nil *BatchOptions uses the defaults.
+type BatchOptions struct {
+	// Concurrency is the number of requests in flight at once; 8 when zero
+	Concurrency int
+}
+
+// ItemResult is the outcome of fetching a single ID of a batch
+type ItemResult struct {
+	ID   int
+	Item Item
+	Err  error
+}
+
+// Returns the number of workers to use, never more than there is work for
+func (o *BatchOptions) workers(n int) int {
+	workers := defaultConcurrency
+	if o != nil && o.Concurrency > 0 {
+		workers = o.Concurrency
+	}
+	if workers > n {
+		workers = n
+	}
+	return workers
+}
+
+// GetItems fetches many items through a pool of workers. Results come back
+// in the order of ids, each with its own error. When ctx is done the
+// remaining IDs are not requested; their results carry the context's error,
+// which is also returned.
+func (c *Client) GetItems(ctx context.Context, ids []int, opts *BatchOptions) ([]ItemResult, error) {
+	results := make([]ItemResult, len(ids))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := opts.workers(len(ids)); w > 0; w-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range indexes {
+				results[n] = c.getItemResult(ctx, ids[n])
+			}
+		}()
+	}
+
+	next := 0
+	func() {
+		defer close(indexes)
+		for ; next < len(ids); next++ {
+			select {
+			case <-ctx.Done():
+				return
+			case indexes <- next:
+			}
+		}
+	}()
+	wg.Wait()
+
+	for n := next; n < len(ids); n++ {
+		results[n] = ItemResult{ID: ids[n], Err: ctx.Err()}
+	}
+
+	return results, ctx.Err()
+}
+
+// Fetches a single item of a batch
+func (c *Client) getItemResult(ctx context.Context, id int) ItemResult {
+	i, err := c.GetItemContext(ctx, id)
+	if err != nil {
+		return ItemResult{ID: id, Err: err}
+	}
+	return ItemResult{ID: id, Item: i}
+}
@@ -0,0 +1,106 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetItems(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var inFlight, maxInFlight int64
+
+	// Set up an API stub answering with a story for even IDs and null for odd ones
+	mux.HandleFunc("/v0/item/", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			max := atomic.LoadInt64(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt64(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		id, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v0/item/"), ".json"))
+		if id%2 == 1 {
+			fmt.Fprint(w, "null")
+			return
+		}
+		fmt.Fprintf(w, `{"id":%d,"type":"story"}`, id)
+	})
+
+	ids := make([]int, 40)
+	for n := range ids {
+		ids[n] = 1000 - n
+	}
+
+	results, err := client.GetItems(context.Background(), ids, &BatchOptions{Concurrency: 4})
+	if err != nil {
+		t.Errorf("Error for client.GetItems should have been nil. Was: %v", err)
+	}
+
+	// Makes sure results are in input order, each with its own error
+	for n, r := range results {
+		if r.ID != ids[n] {
+			t.Errorf("Result %d is for ID %d, was expecting %d", n, r.ID, ids[n])
+		}
+		if ids[n]%2 == 1 {
+			if !errors.Is(r.Err, ErrNotFound) || r.Item != nil {
+				t.Errorf("Result for ID %d was %+v, was expecting %v", ids[n], r, ErrNotFound)
+			}
+		} else if r.Err != nil || r.Item.ID() != ids[n] {
+			t.Errorf("Result for ID %d was %+v, was expecting the item", ids[n], r)
+		}
+	}
+
+	// Makes sure the pool size was respected
+	if max := atomic.LoadInt64(&maxInFlight); max > 4 {
+		t.Errorf("client.GetItems had %d requests in flight, was expecting at most 4", max)
+	}
+}
+
+func TestGetItemsCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int64
+
+	mux.HandleFunc("/v0/item/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	ids := make([]int, 100)
+	for n := range ids {
+		ids[n] = n + 1
+	}
+
+	results, err := client.GetItems(ctx, ids, &BatchOptions{Concurrency: 2})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("client.GetItems returned %v, was expecting %v", err, context.DeadlineExceeded)
+	}
+
+	// Makes sure no more requests were made once the deadline passed
+	if n := atomic.LoadInt64(&requests); n > 2 {
+		t.Errorf("client.GetItems made %d requests, was expecting it to stop at 2", n)
+	}
+
+	for _, r := range results {
+		if !errors.Is(r.Err, context.DeadlineExceeded) {
+			t.Errorf("Result for ID %d was %+v, was expecting %v", r.ID, r, context.DeadlineExceeded)
+			break
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package gophernews
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw API responses under their endpoint path, e.g.
+// "item/8863" or "topstories". The Client consults it before making a
+// request. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, unless it has expired
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for ttl
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete removes the value stored under key, if any
+	Delete(key string)
+}
+
+// CachePolicy sets how long each class of API response stays cached.
+// A zero TTL keeps that class out of the cache. /maxitem and /updates are
+// never cached.
+type CachePolicy struct {
+	// ItemTTL applies to items that may still change: new votes, edits, replies
+	ItemTTL time.Duration
+	// FrozenItemTTL applies to items that no longer change: deleted ones
+	// and those older than FrozenAfter
+	FrozenItemTTL time.Duration
+	// FrozenAfter is the age after which an item no longer changes
+	FrozenAfter time.Duration
+	// UserTTL applies to user profiles
+	UserTTL time.Duration
+	// ListTTL applies to the story lists, e.g. /topstories
+	ListTTL time.Duration
+}
+
+// Returns a policy keeping live items for a minute, items older than two
+// weeks (when Hacker News stops accepting votes and replies) or deleted for
+// a day, profiles for five minutes and story lists for 30 seconds
+func DefaultCachePolicy() *CachePolicy {
+	return &CachePolicy{
+		ItemTTL:       time.Minute,
+		FrozenItemTTL: 24 * time.Hour,
+		FrozenAfter:   14 * 24 * time.Hour,
+		UserTTL:       5 * time.Minute,
+		ListTTL:       30 * time.Second,
+	}
+}
+
+// Returns how long the response body for an API path may be cached
+func (p *CachePolicy) ttl(path string, body []byte, now time.Time) time.Duration {
+	switch {
+	case strings.HasPrefix(path, "item/"):
+		var meta struct {
+			Deleted bool  `json:"deleted"`
+			Time    int64 `json:"time"`
+		}
+		if json.Unmarshal(body, &meta) != nil {
+			return p.ItemTTL
+		}
+		if meta.Deleted || p.FrozenAfter > 0 && now.Sub(time.Unix(meta.Time, 0)) > p.FrozenAfter {
+			return p.FrozenItemTTL
+		}
+		return p.ItemTTL
+	case strings.HasPrefix(path, "user/"):
+		return p.UserTTL
+	case isVolatile(path):
+		return 0
+	default:
+		return p.ListTTL
+	}
+}
+
+// Reports whether an API path changes too often to ever be cached
+func isVolatile(path string) bool {
+	return path == "maxitem" || path == "updates"
+}
+
+// WithCache makes the Client keep responses in cache, for as long as the
+// Client's CachePolicy allows (DefaultCachePolicy unless WithCachePolicy
+// says otherwise)
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.Cache = cache
+	}
+}
+
+// WithCachePolicy sets how long each class of response stays in the Client's cache
+func WithCachePolicy(p *CachePolicy) Option {
+	return func(c *Client) {
+		c.CachePolicy = p
+	}
+}
+
+type noCacheKey struct{}
+
+// Returns a context whose requests skip cache lookups. Their responses are
+// still cached, refreshing what was there.
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// Returns the cached response body for an API path, if any
+func (c *Client) cacheGet(ctx context.Context, path string) ([]byte, bool) {
+	if c.Cache == nil || isVolatile(path) || ctx.Value(noCacheKey{}) != nil {
+		return nil, false
+	}
+	return c.Cache.Get(path)
+}
+
+// Caches the response body for an API path according to the CachePolicy
+func (c *Client) cacheSet(path string, body []byte) {
+	if c.Cache == nil {
+		return
+	}
+
+	policy := c.CachePolicy
+	if policy == nil {
+		policy = DefaultCachePolicy()
+	}
+
+	if ttl := policy.ttl(path, body, time.Now()); ttl > 0 {
+		c.Cache.Set(path, body, ttl)
+	}
+}
+
+// MemoryCache is an in-memory Cache of API responses that evicts the least
+// recently used entries once it is full. It is safe for concurrent use.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	lru        *list.List // of *cacheEntry, most recently used first
+	entries    map[string]*list.Element
+	stats      CacheStats
+}
+
+// CacheStats counts how often a cache could answer
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+	// Evictions counts entries dropped to make room for others
+	Evictions int64
+}
+
+type cacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// Returns a cache holding at most maxEntries responses totalling at most
+// maxBytes. A limit of zero or less leaves that dimension unbounded.
+func NewMemoryCache(maxEntries int, maxBytes int64) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value stored under key, unless it has expired
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		m.stats.Misses++
+		return nil, false
+	}
+
+	entry := e.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		m.remove(e)
+		m.stats.Misses++
+		return nil, false
+	}
+
+	m.lru.MoveToFront(e)
+	m.stats.Hits++
+	return entry.value, true
+}
+
+// Set stores value under key for ttl, evicting the least recently used
+// entries if the cache is full
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		m.remove(e)
+	}
+
+	m.entries[key] = m.lru.PushFront(&cacheEntry{key: key, value: value, expires: time.Now().Add(ttl)})
+	m.bytes += int64(len(value))
+
+	for m.lru.Len() > 1 && (m.maxEntries > 0 && m.lru.Len() > m.maxEntries || m.maxBytes > 0 && m.bytes > m.maxBytes) {
+		m.remove(m.lru.Back())
+		m.stats.Evictions++
+	}
+}
+
+// Delete removes the value stored under key, if any
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		m.remove(e)
+	}
+}
+
+// Len returns the number of entries in the cache, expired ones included
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// Stats returns a snapshot of the cache's counters
+func (m *MemoryCache) Stats() CacheStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
+
+func (m *MemoryCache) remove(e *list.Element) {
+	entry := m.lru.Remove(e).(*cacheEntry)
+	delete(m.entries, entry.key)
+	m.bytes -= int64(len(entry.value))
+}
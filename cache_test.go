@@ -0,0 +1,117 @@
+package gophernews
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheLRU(t *testing.T) {
+	m := NewMemoryCache(2, 0)
+
+	m.Set("item/1", []byte("1"), time.Minute)
+	m.Set("item/2", []byte("2"), time.Minute)
+	m.Get("item/1") // item/2 is now the least recently used
+	m.Set("item/3", []byte("3"), time.Minute)
+
+	if _, ok := m.Get("item/2"); ok {
+		t.Errorf("item/2 should have been evicted")
+	}
+	for _, key := range []string{"item/1", "item/3"} {
+		if _, ok := m.Get(key); !ok {
+			t.Errorf("%s should still be cached", key)
+		}
+	}
+
+	expected := CacheStats{Hits: 3, Misses: 1, Evictions: 1}
+	if stats := m.Stats(); stats != expected {
+		t.Errorf("m.Stats() = %+v, was expecting %+v", stats, expected)
+	}
+
+	// Makes sure the byte limit is enforced too
+	m = NewMemoryCache(0, 10)
+	m.Set("item/1", []byte("123456"), time.Minute)
+	m.Set("item/2", []byte("123456"), time.Minute)
+	if _, ok := m.Get("item/1"); ok || m.Len() != 1 {
+		t.Errorf("item/1 should have been evicted to stay under 10 bytes")
+	}
+
+	// Makes sure expired entries are not returned
+	m.Set("item/3", []byte("3"), -time.Second)
+	if _, ok := m.Get("item/3"); ok {
+		t.Errorf("item/3 should have expired")
+	}
+
+	m.Delete("item/2")
+	if m.Len() != 0 {
+		t.Errorf("m.Len() = %d after deleting every entry", m.Len())
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int64
+	count := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt64(&requests, 1)
+			fmt.Fprint(w, body)
+		}
+	}
+
+	// Set up API stubs
+	mux.HandleFunc("/v0/item/8863.json", count(`{"by":"dhouston","id":8863,"time":1175714200,"type":"story"}`))
+	mux.HandleFunc("/v0/item/1.json", count(`null`))
+	mux.HandleFunc("/v0/maxitem.json", count(`8863`))
+
+	cache := NewMemoryCache(100, 0)
+	client.Cache = cache
+
+	for n := 0; n < 3; n++ {
+		if s, err := client.GetStory(8863); err != nil || s.ID != 8863 {
+			t.Errorf("client.GetStory(8863) returned %+v, %v", s, err)
+		}
+		client.GetItem(1)
+		client.GetMaxItem()
+	}
+
+	// The story is fetched once; nulls and /maxitem are never cached
+	if requests != 1+3+3 {
+		t.Errorf("The server saw %d requests, was expecting 7", requests)
+	}
+
+	expected := CacheStats{Hits: 5, Misses: 4}
+	if stats := cache.Stats(); stats != expected {
+		t.Errorf("cache.Stats() = %+v, was expecting %+v", stats, expected)
+	}
+}
+
+func TestCachePolicyTTL(t *testing.T) {
+	p := DefaultCachePolicy()
+	now := time.Date(2015, 1, 7, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-time.Hour).Unix()
+	old := now.AddDate(-1, 0, 0).Unix()
+
+	cases := []struct {
+		path     string
+		body     string
+		expected time.Duration
+	}{
+		{"item/1", fmt.Sprintf(`{"id":1,"time":%d}`, recent), p.ItemTTL},
+		{"item/1", fmt.Sprintf(`{"id":1,"time":%d}`, old), p.FrozenItemTTL},
+		{"item/1", fmt.Sprintf(`{"deleted":true,"id":1,"time":%d}`, recent), p.FrozenItemTTL},
+		{"user/pg", `{"id":"pg"}`, p.UserTTL},
+		{"topstories", `[1,2,3]`, p.ListTTL},
+		{"maxitem", `3`, 0},
+		{"updates", `{}`, 0},
+	}
+
+	for _, c := range cases {
+		if ttl := p.ttl(c.path, []byte(c.body), now); ttl != c.expected {
+			t.Errorf("ttl(%q, %s) = %v, was expecting %v", c.path, c.body, ttl, c.expected)
+		}
+	}
+}
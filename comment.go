@@ -1,11 +1,15 @@
 package gophernews
 
+// Comment was first generated by gojson from json/2921983.json, and is now
+// maintained by hand
 type Comment struct {
-	By     string `json:"by"`
-	ID     int    `json:"id"`
-	Kids   []int  `json:"kids"`
-	Parent int    `json:"parent"`
-	Text   string `json:"text"`
-	Time   int    `json:"time"`
-	Type   string `json:"type"`
+	By      string    `json:"by"`
+	Dead    bool      `json:"dead"`
+	Deleted bool      `json:"deleted"`
+	ID      int       `json:"id"`
+	Kids    []int     `json:"kids"`
+	Parent  int       `json:"parent"`
+	Text    string    `json:"text"`
+	Time    Timestamp `json:"time"`
+	Type    string    `json:"type"`
 }
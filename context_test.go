@@ -0,0 +1,53 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetItemContextDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up an API stub that hangs until the client gives up
+	mux.HandleFunc("/v0/item/8863.json", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetItemContext(ctx, 8863)
+
+	// Makes sure the deadline was carried down to the HTTP request
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("client.GetItemContext(ctx, 8863) returned %v, was expecting %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestGetStoryContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0/item/8863.json", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("client.GetStoryContext made a request with an already canceled context")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s, err := client.GetStoryContext(ctx, 8863)
+
+	// Makes sure the cancellation was reported
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("client.GetStoryContext(ctx, 8863) returned %v, was expecting %v", err, context.Canceled)
+	}
+
+	// Checks to make sure method returns an empty Story object
+	if s.ID != 0 {
+		t.Errorf("client.GetStoryContext(ctx, 8863) returned %+v, should have been empty", s)
+	}
+}
@@ -0,0 +1,454 @@
+package gophernews
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Sink receives every item a Crawler fetches. Put is called from many
+// goroutines at once. An error from Put stops the crawl; the item is
+// fetched again when the crawl resumes.
+type Sink interface {
+	Put(ctx context.Context, i Item) error
+}
+
+// JSONLinesSink is a Sink writing one JSON item per line
+type JSONLinesSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// Returns a sink writing to w
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{enc: json.NewEncoder(w)}
+}
+
+// Put writes i as a line of JSON
+func (s *JSONLinesSink) Put(ctx context.Context, i Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(i)
+}
+
+// Checkpoint is how far a crawl got. Every ID from Start down to, but not
+// including, Next is done, except for those in Failed. IDs below Next may
+// be done too, but a resumed crawl fetches them again.
+type Checkpoint struct {
+	Start   int   `json:"start"`
+	End     int   `json:"end"`
+	Next    int   `json:"next"`
+	Done    int64 `json:"done"`
+	Missing int64 `json:"missing"`
+	Errors  int64 `json:"errors"`
+	// Failed are the IDs above Next that could not be fetched. A resumed
+	// crawl retries them first.
+	Failed []int `json:"failed,omitempty"`
+}
+
+// CheckpointStore persists a crawl's Checkpoint so it can resume after a crash
+type CheckpointStore interface {
+	// Load returns the saved checkpoint, or nil if there is none
+	Load() (*Checkpoint, error)
+	Save(*Checkpoint) error
+}
+
+// FileCheckpoint is a CheckpointStore keeping the checkpoint in a JSON file
+type FileCheckpoint struct {
+	Path string
+}
+
+// Load reads the checkpoint file, returning nil if it doesn't exist yet
+func (f FileCheckpoint) Load() (*Checkpoint, error) {
+	body, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cp Checkpoint
+	if err := json.Unmarshal(body, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// Save replaces the checkpoint file. The file is written in full before
+// it replaces the previous one, so a crash never leaves half a checkpoint.
+func (f FileCheckpoint) Save(cp *Checkpoint) error {
+	body, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(f.Path), ".checkpoint-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// CrawlerOptions configures a Crawler
+type CrawlerOptions struct {
+	// Start is the highest ID to crawl; the current /maxitem when zero
+	Start int
+	// End is the lowest ID to crawl; 1 when zero
+	End int
+	// Concurrency is the number of requests in flight at once; 16 when zero
+	Concurrency int
+	// Sink receives every item fetched. It is required.
+	Sink Sink
+	// Checkpoint persists progress, so a crawl resumes where it stopped.
+	// A saved checkpoint for the same range overrides Start.
+	Checkpoint CheckpointStore
+	// CheckpointInterval is the time between two saves; 10 seconds when zero
+	CheckpointInterval time.Duration
+	// OnError is called for items that could not be fetched, from the
+	// worker goroutines, so possibly for several IDs at once. Those items
+	// are counted in Progress.Errors, kept in Checkpoint.Failed and
+	// retried when the crawl is resumed.
+	OnError func(id int, err error)
+	// OnProgress is called every ProgressInterval (10 seconds when zero)
+	OnProgress       func(Progress)
+	ProgressInterval time.Duration
+}
+
+// Progress reports how far a crawl got
+type Progress struct {
+	// Done counts the IDs handled, including Missing and Errors
+	Done int64
+	// Missing counts the IDs the API answered null for
+	Missing int64
+	// Errors counts the IDs that could not be fetched, and are left to retry
+	Errors int64
+	// Remaining counts the IDs left to handle
+	Remaining int64
+	// Rate is the number of IDs handled per second since Run was called
+	Rate float64
+	// ETA is the time left at the current rate
+	ETA time.Duration
+}
+
+// Crawler walks a range of item IDs downward, from the newest to the
+// oldest, with many workers, and hands every item to a Sink
+type Crawler struct {
+	client *Client
+	opts   CrawlerOptions
+
+	mu        sync.Mutex
+	cp        Checkpoint
+	completed map[int]crawlResult // IDs done below cp.Next, waiting for the ones above them
+	pending   Checkpoint          // counts for the IDs in completed
+	started   time.Time
+	doneAtRun int64
+}
+
+// Returns a crawler fetching items through the Client. Nothing happens
+// until Run is called.
+func (c *Client) NewCrawler(opts *CrawlerOptions) *Crawler {
+	cr := &Crawler{client: c}
+	if opts != nil {
+		cr.opts = *opts
+	}
+
+	if cr.opts.End <= 0 {
+		cr.opts.End = 1
+	}
+	if cr.opts.Concurrency <= 0 {
+		cr.opts.Concurrency = 16
+	}
+	if cr.opts.CheckpointInterval <= 0 {
+		cr.opts.CheckpointInterval = 10 * time.Second
+	}
+	if cr.opts.ProgressInterval <= 0 {
+		cr.opts.ProgressInterval = 10 * time.Second
+	}
+	return cr
+}
+
+// Run retries the IDs that failed before, then crawls until every ID of
+// the range is done, ctx is done or the Sink fails. The checkpoint is
+// saved on the way out in every case.
+func (cr *Crawler) Run(ctx context.Context) error {
+	if cr.opts.Sink == nil {
+		return errors.New("gophernews: crawler needs a sink")
+	}
+
+	if err := cr.resume(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ids := make(chan int)
+	var sinkErr error
+	var sinkOnce sync.Once
+
+	var wg sync.WaitGroup
+	for w := 0; w < cr.opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				if err := cr.crawl(ctx, id); err != nil {
+					sinkOnce.Do(func() {
+						sinkErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+	stopped := make(chan struct{})
+	reported := make(chan struct{})
+	go func() {
+		defer close(reported)
+		cr.report(stopped)
+	}()
+
+	cr.mu.Lock()
+	next := cr.cp.Next
+	retries := append([]int(nil), cr.cp.Failed...)
+	cr.mu.Unlock()
+
+	func() {
+		defer close(ids)
+		for _, id := range retries {
+			select {
+			case <-ctx.Done():
+				return
+			case ids <- id:
+			}
+		}
+		for id := next; id >= cr.opts.End; id-- {
+			select {
+			case <-ctx.Done():
+				return
+			case ids <- id:
+			}
+		}
+	}()
+	wg.Wait()
+	close(stopped)
+	// A periodic save still running must not overwrite the final one
+	<-reported
+
+	saveErr := cr.save()
+
+	switch {
+	case sinkErr != nil:
+		return sinkErr
+	case ctx.Err() != nil && cr.Progress().Remaining > 0:
+		return ctx.Err()
+	}
+	return saveErr
+}
+
+// Progress returns how far the crawl got
+func (cr *Crawler) Progress() Progress {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	p := Progress{
+		Done:    cr.cp.Done + cr.pending.Done,
+		Missing: cr.cp.Missing + cr.pending.Missing,
+		Errors:  cr.cp.Errors + cr.pending.Errors,
+	}
+	p.Remaining = int64(cr.cp.Start-cr.opts.End+1) - p.Done
+
+	if elapsed := time.Since(cr.started); !cr.started.IsZero() && elapsed > 0 {
+		p.Rate = float64(p.Done-cr.doneAtRun) / elapsed.Seconds()
+		if p.Rate > 0 {
+			p.ETA = time.Duration(float64(p.Remaining) / p.Rate * float64(time.Second))
+		}
+	}
+
+	return p
+}
+
+// Loads the saved checkpoint, or starts a new one
+func (cr *Crawler) resume(ctx context.Context) error {
+	var saved *Checkpoint
+	if cr.opts.Checkpoint != nil {
+		var err error
+		if saved, err = cr.opts.Checkpoint.Load(); err != nil {
+			return err
+		}
+	}
+
+	start := cr.opts.Start
+	if saved != nil && saved.End == cr.opts.End && (start == 0 || start == saved.Start) {
+		start = saved.Start
+	} else {
+		saved = nil
+	}
+
+	if start == 0 {
+		max, err := cr.client.getMaxItemID(ctx)
+		if err != nil {
+			return err
+		}
+		start = max
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	if saved != nil {
+		cr.cp = *saved
+	} else {
+		cr.cp = Checkpoint{Start: start, End: cr.opts.End, Next: start}
+	}
+	cr.completed = make(map[int]crawlResult)
+	cr.pending = Checkpoint{}
+	cr.started = time.Now()
+	cr.doneAtRun = cr.cp.Done
+
+	return nil
+}
+
+// Fetches an item and hands it to the Sink. Only an error from the Sink is returned.
+func (cr *Crawler) crawl(ctx context.Context, id int) error {
+	i, err := cr.client.GetItemContext(ctx, id)
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	result := crawlStored
+	switch {
+	case errors.Is(err, ErrNotFound):
+		result = crawlMissing
+	case err != nil:
+		result = crawlFailed
+		if cr.opts.OnError != nil {
+			cr.opts.OnError(id, err)
+		}
+	default:
+		if err := cr.opts.Sink.Put(ctx, i); err != nil {
+			return err
+		}
+	}
+
+	cr.complete(id, result)
+	return nil
+}
+
+type crawlResult int
+
+const (
+	crawlStored crawlResult = iota
+	crawlMissing
+	crawlFailed
+)
+
+// Adds n IDs with the given result to the counts of cp
+func (cp *Checkpoint) count(result crawlResult, n int64) {
+	cp.Done += n
+	switch result {
+	case crawlMissing:
+		cp.Missing += n
+	case crawlFailed:
+		cp.Errors += n
+	}
+}
+
+// Marks an ID done and moves the checkpoint down past every ID done in a
+// row. IDs below the checkpoint are counted apart, since a resumed crawl
+// fetches them again.
+func (cr *Crawler) complete(id int, result crawlResult) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	if id > cr.cp.Next {
+		// A retry of an ID in Failed, which stays there if it failed again
+		if result != crawlFailed {
+			cr.cp.Errors--
+			if result == crawlMissing {
+				cr.cp.Missing++
+			}
+			cr.cp.Failed = removeID(cr.cp.Failed, id)
+		}
+		return
+	}
+
+	cr.completed[id] = result
+	cr.pending.count(result, 1)
+
+	for {
+		result, ok := cr.completed[cr.cp.Next]
+		if !ok {
+			break
+		}
+		delete(cr.completed, cr.cp.Next)
+		cr.pending.count(result, -1)
+		cr.cp.count(result, 1)
+		if result == crawlFailed {
+			cr.cp.Failed = append(cr.cp.Failed, cr.cp.Next)
+		}
+		cr.cp.Next--
+	}
+}
+
+// Saves the checkpoint and reports progress on their intervals until stopped
+func (cr *Crawler) report(stopped chan struct{}) {
+	checkpoints := time.NewTicker(cr.opts.CheckpointInterval)
+	defer checkpoints.Stop()
+	progress := time.NewTicker(cr.opts.ProgressInterval)
+	defer progress.Stop()
+
+	for {
+		select {
+		case <-stopped:
+			return
+		case <-checkpoints.C:
+			cr.save()
+		case <-progress.C:
+			if cr.opts.OnProgress != nil {
+				cr.opts.OnProgress(cr.Progress())
+			}
+		}
+	}
+}
+
+func (cr *Crawler) save() error {
+	if cr.opts.Checkpoint == nil {
+		return nil
+	}
+
+	cr.mu.Lock()
+	cp := cr.cp
+	cp.Failed = append([]int(nil), cr.cp.Failed...)
+	cr.mu.Unlock()
+
+	return cr.opts.Checkpoint.Save(&cp)
+}
+
+// Returns ids without id
+func removeID(ids []int, id int) []int {
+	for n := range ids {
+		if ids[n] == id {
+			return append(ids[:n], ids[n+1:]...)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,320 @@
+package gophernews
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A Sink collecting item IDs, failing on one of them if asked to
+type idSink struct {
+	mu     sync.Mutex
+	ids    []int
+	failOn int
+}
+
+func (s *idSink) Put(ctx context.Context, i Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i.ID() == s.failOn {
+		return errors.New("disk full")
+	}
+	s.ids = append(s.ids, i.ID())
+	return nil
+}
+
+func (s *idSink) sorted() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := append([]int(nil), s.ids...)
+	sort.Ints(ids)
+	return ids
+}
+
+// Serves items 1 to 40, where every tenth one is null and 13 always fails
+func setupCrawl() {
+	mux.HandleFunc("/v0/maxitem.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, 40)
+	})
+	mux.HandleFunc("/v0/item/", func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		fmt.Sscanf(r.URL.Path, "/v0/item/%d.json", &id)
+		switch {
+		case id == 13:
+			http.Error(w, "teapot", http.StatusTeapot)
+		case id%10 == 0 || id > 40:
+			fmt.Fprint(w, "null")
+		default:
+			fmt.Fprintf(w, `{"id":%d,"type":"comment","by":"pg"}`, id)
+		}
+	})
+}
+
+func crawledIDs() []int {
+	var ids []int
+	for id := 1; id <= 40; id++ {
+		if id != 13 && id%10 != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Makes sure a crawl stores every item, skips the null ones and reports
+// the failed one
+func TestCrawler(t *testing.T) {
+	setup()
+	defer teardown()
+	setupCrawl()
+
+	sink := &idSink{}
+	var mu sync.Mutex
+	var failed []int
+	cr := client.NewCrawler(&CrawlerOptions{
+		Concurrency: 4,
+		Sink:        sink,
+		OnError: func(id int, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, id)
+		},
+	})
+	if err := cr.Run(context.Background()); err != nil {
+		t.Fatalf("Error for cr.Run should have been nil. Was: %v", err)
+	}
+
+	if ids, expected := sink.sorted(), crawledIDs(); fmt.Sprint(ids) != fmt.Sprint(expected) {
+		t.Errorf("Sink stored %v, was expecting %v", ids, expected)
+	}
+	if fmt.Sprint(failed) != "[13]" {
+		t.Errorf("OnError was called for %v, was expecting [13]", failed)
+	}
+
+	p := cr.Progress()
+	if p.Done != 40 || p.Missing != 4 || p.Errors != 1 || p.Remaining != 0 {
+		t.Errorf("Progress was %+v, was expecting 40 done, 4 missing, 1 error and none remaining", p)
+	}
+}
+
+// Makes sure a crawl stopped by the sink resumes from its checkpoint
+// without fetching what it already did
+func TestCrawlerResume(t *testing.T) {
+	setup()
+	defer teardown()
+	setupCrawl()
+
+	store := FileCheckpoint{Path: filepath.Join(t.TempDir(), "crawl.json")}
+
+	first := &idSink{failOn: 25}
+	cr := client.NewCrawler(&CrawlerOptions{Concurrency: 4, Sink: first, Checkpoint: store})
+	if err := cr.Run(context.Background()); err == nil || err.Error() != "disk full" {
+		t.Fatalf("Error for cr.Run should have been the sink error. Was: %v", err)
+	}
+
+	cp, err := store.Load()
+	if err != nil || cp == nil {
+		t.Fatalf("store.Load should have returned a checkpoint. Was: %v, %v", cp, err)
+	}
+	if cp.Start != 40 || cp.End != 1 || cp.Next < 25 {
+		t.Fatalf("Checkpoint was %+v, was expecting Next at 25 or above", cp)
+	}
+
+	second := &idSink{}
+	cr = client.NewCrawler(&CrawlerOptions{Concurrency: 4, Sink: second, Checkpoint: store})
+	if err := cr.Run(context.Background()); err != nil {
+		t.Fatalf("Error for the resumed cr.Run should have been nil. Was: %v", err)
+	}
+
+	for _, id := range second.sorted() {
+		if id > cp.Next {
+			t.Errorf("Resumed crawl fetched %d above the checkpoint %d", id, cp.Next)
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, id := range append(first.sorted(), second.sorted()...) {
+		seen[id] = true
+	}
+	for _, id := range crawledIDs() {
+		if !seen[id] {
+			t.Errorf("Item %d was never crawled", id)
+		}
+	}
+
+	if cp, _ := store.Load(); cp.Next != 0 || cp.Done != 40 {
+		t.Errorf("Final checkpoint was %+v, was expecting the crawl to be done", cp)
+	}
+}
+
+// Makes sure an item that failed is kept in the checkpoint and fetched
+// again when the crawl is resumed
+func TestCrawlerRetriesFailed(t *testing.T) {
+	setup()
+	defer teardown()
+	setupCrawl()
+
+	store := FileCheckpoint{Path: filepath.Join(t.TempDir(), "crawl.json")}
+
+	cr := client.NewCrawler(&CrawlerOptions{Concurrency: 4, Sink: &idSink{}, Checkpoint: store})
+	if err := cr.Run(context.Background()); err != nil {
+		t.Fatalf("Error for cr.Run should have been nil. Was: %v", err)
+	}
+
+	cp, err := store.Load()
+	if err != nil {
+		t.Fatalf("Error for store.Load should have been nil. Was: %v", err)
+	}
+	if fmt.Sprint(cp.Failed) != "[13]" || cp.Errors != 1 {
+		t.Fatalf("Checkpoint failed IDs were %v with %d errors, was expecting [13] with 1 error", cp.Failed, cp.Errors)
+	}
+
+	mux.HandleFunc("/v0/item/13.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":13,"type":"comment","by":"pg"}`)
+	})
+
+	sink := &idSink{}
+	cr = client.NewCrawler(&CrawlerOptions{Concurrency: 4, Sink: sink, Checkpoint: store})
+	if err := cr.Run(context.Background()); err != nil {
+		t.Fatalf("Error for cr.Run should have been nil. Was: %v", err)
+	}
+
+	if ids := sink.sorted(); fmt.Sprint(ids) != "[13]" {
+		t.Errorf("Resumed crawl stored %v, was expecting only [13]", ids)
+	}
+
+	cp, err = store.Load()
+	if err != nil {
+		t.Fatalf("Error for store.Load should have been nil. Was: %v", err)
+	}
+	if len(cp.Failed) != 0 || cp.Errors != 0 || cp.Done != 40 || cp.Missing != 4 {
+		t.Errorf("Checkpoint was %+v, was expecting 40 done, 4 missing and no errors", cp)
+	}
+}
+
+// Makes sure a canceled crawl returns the context's error
+func TestCrawlerCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+	setupCrawl()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cr := client.NewCrawler(&CrawlerOptions{Start: 30, End: 11, Sink: &idSink{}})
+	if err := cr.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Error for cr.Run should have been context.Canceled. Was: %v", err)
+	}
+	if p := cr.Progress(); p.Remaining+p.Done != 20 {
+		t.Errorf("Progress was %+v, was expecting a range of 20 IDs", p)
+	}
+}
+
+// Makes sure OnProgress is called while the crawl runs
+func TestCrawlerProgress(t *testing.T) {
+	setup()
+	defer teardown()
+	setupCrawl()
+
+	reports := make(chan Progress, 100)
+	cr := client.NewCrawler(&CrawlerOptions{
+		Sink:             &idSink{},
+		OnProgress:       func(p Progress) { reports <- p },
+		ProgressInterval: time.Millisecond,
+	})
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"id":1,"type":"story"}`)
+	})
+	if err := cr.Run(context.Background()); err != nil {
+		t.Fatalf("Error for cr.Run should have been nil. Was: %v", err)
+	}
+
+	select {
+	case p := <-reports:
+		if p.Done+p.Remaining != 40 {
+			t.Errorf("Progress was %+v, was expecting a range of 40 IDs", p)
+		}
+	default:
+		t.Errorf("OnProgress should have been called")
+	}
+	if p := cr.Progress(); p.Rate <= 0 {
+		t.Errorf("Rate was %v, was expecting it above zero", p.Rate)
+	}
+}
+
+// Makes sure JSONLinesSink writes one item per line
+func TestJSONLinesSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLinesSink(&buf)
+	for _, i := range []item{{fields: itemFields{ID: 1}}, {fields: itemFields{ID: 2}}} {
+		if err := sink.Put(context.Background(), i); err != nil {
+			t.Fatalf("Error for sink.Put should have been nil. Was: %v", err)
+		}
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("Sink wrote %d lines, was expecting 2", len(lines))
+	}
+	var second map[string]int
+	if err := json.Unmarshal(lines[1], &second); err != nil || second["id"] != 2 {
+		t.Errorf("Second line was %s, was expecting item 2", lines[1])
+	}
+}
+
+// A CheckpointStore whose saves before the end of the crawl are slow
+type slowCheckpoint struct {
+	mu    sync.Mutex
+	saved *Checkpoint
+}
+
+func (s *slowCheckpoint) Load() (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saved, nil
+}
+
+func (s *slowCheckpoint) Save(cp *Checkpoint) error {
+	if cp.Next != 0 {
+		time.Sleep(50 * time.Millisecond)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved := *cp
+	s.saved = &saved
+	return nil
+}
+
+// Makes sure a periodic save still running when the crawl ends can't
+// overwrite the final checkpoint
+func TestCrawlerFinalCheckpoint(t *testing.T) {
+	setup()
+	defer teardown()
+	setupCrawl()
+
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"id":1,"type":"story"}`)
+	})
+
+	store := &slowCheckpoint{}
+	cr := client.NewCrawler(&CrawlerOptions{Sink: &idSink{}, Checkpoint: store, CheckpointInterval: time.Millisecond})
+	if err := cr.Run(context.Background()); err != nil {
+		t.Fatalf("Error for cr.Run should have been nil. Was: %v", err)
+	}
+
+	// Gives a periodic save left running the time to overwrite the final one
+	time.Sleep(100 * time.Millisecond)
+
+	if cp, _ := store.Load(); cp == nil || cp.Next != 0 || cp.Done != 40 {
+		t.Errorf("Final checkpoint is %+v, was expecting the crawl to be done", cp)
+	}
+}
@@ -0,0 +1,69 @@
+package gophernews
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrNotFound is matched by errors.Is for every error caused by a missing
+// item, user or endpoint
+var ErrNotFound = errors.New("gophernews: not found")
+
+// ErrDeleted is returned by the typed getters for deleted items when the
+// Client skips them
+var ErrDeleted = errors.New("gophernews: item is deleted")
+
+// ErrDead is returned by the typed getters for dead items when the Client
+// skips them
+var ErrDead = errors.New("gophernews: item is dead")
+
+// ErrUnknownType is returned by GetAny for items of a type this package
+// does not know
+var ErrUnknownType = errors.New("gophernews: unknown item type")
+
+// HTTPError is returned when the API answers with a non-2xx status code
+type HTTPError struct {
+	URL        string
+	StatusCode int
+	Body       []byte
+	// RetryAfter is the wait the server asked for, zero when it did not say
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("gophernews: GET %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is makes a 404 match ErrNotFound
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// TypeMismatchError is returned when a typed getter such as GetStory is
+// called with the ID of an item of another type
+type TypeMismatchError struct {
+	ID       int
+	Expected string
+	Actual   string
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("gophernews: item %d is of type %q, not %q", e.ID, e.Actual, e.Expected)
+}
+
+// DecodeError is returned when a response body is not the JSON expected
+type DecodeError struct {
+	URL  string
+	Body []byte
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("gophernews: decoding %s: %v", e.URL, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
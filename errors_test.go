@@ -0,0 +1,145 @@
+package gophernews
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNotFoundError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stub
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	_, err := client.GetItem(1)
+
+	// Makes sure a 404 matches ErrNotFound
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("client.GetItem(1) returned %v, was expecting %v", err, ErrNotFound)
+	}
+
+	// Makes sure the status and body are available
+	var he *HTTPError
+	if !errors.As(err, &he) {
+		t.Fatalf("client.GetItem(1) returned %T, was expecting *HTTPError", err)
+	}
+	if he.StatusCode != http.StatusNotFound || len(he.Body) == 0 {
+		t.Errorf("client.GetItem(1) returned %+v, was expecting a 404 with a body", he)
+	}
+}
+
+func TestTypeMismatchError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0/item/8952.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"by":"dhouston","type":"comment"}`)
+	})
+
+	_, err := client.GetStory(8952)
+
+	var tme *TypeMismatchError
+	if !errors.As(err, &tme) {
+		t.Fatalf("client.GetStory(8952) returned %v, was expecting a *TypeMismatchError", err)
+	}
+
+	expected := TypeMismatchError{ID: 8952, Expected: "story", Actual: "comment"}
+	if *tme != expected {
+		t.Errorf("client.GetStory(8952) returned %+v, was expecting %+v", *tme, expected)
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0/topstories.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"not":"a list"}`)
+	})
+
+	_, err := client.GetTop100()
+
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("client.GetTop100() returned %v, was expecting a *DecodeError", err)
+	}
+	if string(de.Body) != `{"not":"a list"}` || de.Unwrap() == nil {
+		t.Errorf("client.GetTop100() returned %+v, was expecting the body and the JSON error", de)
+	}
+}
+
+func TestNullResponses(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stubs answering like Firebase does for missing objects
+	null := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "null\n")
+	}
+	mux.HandleFunc("/v0/item/99999999.json", null)
+	mux.HandleFunc("/v0/user/nobody-by-that-name.json", null)
+	mux.HandleFunc("/v0/maxitem.json", null)
+
+	if i, err := client.GetItem(99999999); !errors.Is(err, ErrNotFound) || i.ID() != 0 {
+		t.Errorf("client.GetItem(99999999) returned %+v, %v, was expecting an empty item and %v", i, err, ErrNotFound)
+	}
+
+	if s, err := client.GetStory(99999999); !errors.Is(err, ErrNotFound) || s.ID != 0 {
+		t.Errorf("client.GetStory(99999999) returned %+v, %v, was expecting an empty Story and %v", s, err, ErrNotFound)
+	}
+
+	if u, err := client.GetUser("nobody-by-that-name"); !errors.Is(err, ErrNotFound) || u.ID != "" {
+		t.Errorf("client.GetUser(\"nobody-by-that-name\") returned %+v, %v, was expecting an empty User and %v", u, err, ErrNotFound)
+	}
+
+	if _, err := client.GetMaxItem(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("client.GetMaxItem() returned %v, was expecting %v", err, ErrNotFound)
+	}
+}
+
+func TestDeletedAndDeadItems(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stubs
+	mux.HandleFunc("/v0/item/2922097.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"deleted":true,"id":2922097,"parent":2921983,"time":1314211500,"type":"comment"}`)
+	})
+	mux.HandleFunc("/v0/item/2922429.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"by":"spammer","dead":true,"id":2922429,"parent":2921983,"text":"[flagged]","time":1314211600,"type":"comment"}`)
+	})
+
+	// Deleted and dead items are included by default
+	c, err := client.GetComment(2922097)
+	if err != nil || !c.Deleted || c.Dead {
+		t.Errorf("client.GetComment(2922097) returned %+v, %v, was expecting a deleted comment", c, err)
+	}
+
+	c, err = client.GetComment(2922429)
+	if err != nil || !c.Dead || c.Deleted {
+		t.Errorf("client.GetComment(2922429) returned %+v, %v, was expecting a dead comment", c, err)
+	}
+
+	// Makes sure they are skipped when asked
+	client.SkipDeleted = true
+	client.SkipDead = true
+
+	if _, err := client.GetComment(2922097); !errors.Is(err, ErrDeleted) {
+		t.Errorf("client.GetComment(2922097) returned %v, was expecting %v", err, ErrDeleted)
+	}
+
+	if _, err := client.GetComment(2922429); !errors.Is(err, ErrDead) {
+		t.Errorf("client.GetComment(2922429) returned %v, was expecting %v", err, ErrDead)
+	}
+
+	// GetItem always returns the item as is
+	i, err := client.GetItem(2922097)
+	if err != nil || !i.Deleted() {
+		t.Errorf("client.GetItem(2922097) returned %v, %v, was expecting a deleted item", i, err)
+	}
+}
@@ -0,0 +1,149 @@
+package gophernews
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileCache is a Cache keeping raw JSON responses on disk, in one flat
+// folder laid out like the json/ folder of examples: items by ID
+// (8863.json) and users by name (chimeracoder.json). As a username may be
+// all digits, those users and the other responses get a ~ in front
+// (~1234.json, ~topstories.json), which is never part of an ID or a
+// username. It survives restarts, so tools that run often don't refetch
+// what they already have.
+//
+// A file's modification time is set to its expiry. Failures to read or
+// write the cache, and keys of any other shape, are treated as misses.
+type FileCache struct {
+	dir string
+}
+
+// Returns a cache storing responses under dir, which is created if needed
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// Get returns the response stored under key, unless it has expired
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	path, ok := f.path(key)
+	if !ok {
+		return nil, false
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+
+	if time.Now().After(info.ModTime()) {
+		os.Remove(path)
+		return nil, false
+	}
+
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	return body, true
+}
+
+// Set stores value under key for ttl. The file is written in full before
+// it replaces any previous version, so readers never see half a response.
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	path, ok := f.path(key)
+	if !ok {
+		return
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(value)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+
+	expires := time.Now().Add(ttl)
+	if err := os.Chtimes(tmp.Name(), expires, expires); err != nil {
+		return
+	}
+
+	os.Rename(tmp.Name(), path)
+}
+
+// Delete removes the response stored under key, if any
+func (f *FileCache) Delete(key string) {
+	if path, ok := f.path(key); ok {
+		os.Remove(path)
+	}
+}
+
+// Returns the file for a key, refusing keys that don't map to one of the
+// cache's own files
+func (f *FileCache) path(key string) (string, bool) {
+	var name string
+	switch {
+	case strings.HasPrefix(key, "item/"):
+		name = strings.TrimPrefix(key, "item/")
+		if !isDigits(name) {
+			return "", false
+		}
+	case strings.HasPrefix(key, "user/"):
+		name = strings.TrimPrefix(key, "user/")
+		if !isUsername(name) {
+			return "", false
+		}
+		if isDigits(name) {
+			name = "~" + name
+		}
+	default:
+		if !isUsername(key) {
+			return "", false
+		}
+		name = "~" + key
+	}
+	return filepath.Join(f.dir, name+".json"), true
+}
+
+// Reports whether s is made of digits only
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether s only has the characters Hacker News allows in a
+// username: letters, digits, dashes and underscores
+func isUsername(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package gophernews
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+
+	f, err := NewFileCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"item/8863":         "8863.json",
+		"user/chimeracoder": "chimeracoder.json",
+		"user/8863":         "~8863.json",
+		"topstories":        "~topstories.json",
+	}
+	for key := range files {
+		f.Set(key, []byte(`"`+key+`"`), time.Minute)
+	}
+
+	// Makes sure the files are laid out like json/, with all-digit
+	// usernames kept apart from item IDs
+	for key, name := range files {
+		body, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s was not stored in %s: %v", key, name, err)
+		}
+		if cached, ok := f.Get(key); !ok || string(cached) != string(body) {
+			t.Errorf("f.Get(%q) = %s, %v, was expecting %s", key, cached, ok, body)
+		}
+	}
+
+	// Makes sure expired entries are not returned
+	f.Set("user/pg", []byte(`{"id":"pg"}`), -time.Second)
+	if _, ok := f.Get("user/pg"); ok {
+		t.Errorf("user/pg should have expired")
+	}
+
+	f.Delete("item/8863")
+	if _, ok := f.Get("item/8863"); ok {
+		t.Errorf("item/8863 should have been deleted")
+	}
+
+	// Makes sure keys of any other shape are misses and can't escape the directory
+	for _, key := range []string{"../escaped", "user/../escaped", "item/12x", "user/", "v0/item/1"} {
+		f.Set(key, []byte(`{}`), time.Minute)
+		if _, ok := f.Get(key); ok {
+			t.Errorf("f.Get(%q) should have been a miss", key)
+		}
+	}
+	if _, err := ioutil.ReadFile(filepath.Join(dir, "..", "escaped.json")); err == nil {
+		t.Errorf("f.Set(\"../escaped\") wrote outside of the cache's directory")
+	}
+}
+
+func TestClientFileCache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+
+	// Set up API stub
+	mux.HandleFunc("/v0/user/jl.json", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"id":"jl","karma":2937}`)
+	})
+
+	dir := t.TempDir()
+
+	// Two clients sharing a directory, as two runs of a tool would
+	for n := 0; n < 2; n++ {
+		cache, err := NewFileCache(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.Cache = cache
+
+		if u, err := client.GetUser("jl"); err != nil || u.Karma != 2937 {
+			t.Errorf("client.GetUser(\"jl\") returned %+v, %v", u, err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("The server saw %d requests, was expecting 1", requests)
+	}
+}
@@ -1,11 +1,15 @@
 package gophernews
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 // create data structures
@@ -14,196 +18,274 @@ type Client struct {
 	BaseURI string
 	Version string
 	Suffix  string
+
+	// HTTPClient is used for every request; http.DefaultClient when nil
+	HTTPClient *http.Client
+	// UserAgent is sent with every request when not empty
+	UserAgent string
+	// Retry decides how transient failures are retried; nil disables retries
+	Retry *RetryPolicy
+	// Limiter throttles every request, retries included; nil disables throttling
+	Limiter *RateLimiter
+	// SkipDeleted makes the typed getters return ErrDeleted for deleted items
+	SkipDeleted bool
+	// SkipDead makes the typed getters return ErrDead for dead (flagged or killed) items
+	SkipDead bool
+	// Cache keeps responses for reuse; nil disables caching
+	Cache Cache
+	// CachePolicy sets how long responses stay in Cache; DefaultCachePolicy when nil
+	CachePolicy *CachePolicy
 }
 
-// All the struct definitions can be generated automatically using the example JSON provided by the actual API endpoints corresponding to the test cases
+// The Changes struct can be generated automatically using the example JSON provided by the actual API endpoint corresponding to the test case.
+// User and the item structs (Story, Comment, Poll, Part and Job) started out that way but are now maintained by hand.
 // gojson can be installed with `go get github.com/ChimeraCoder/gojson`
 
-//go:generate gojson -o user.go -name "User" -pkg "gophernews" -input json/chimeracoder.json
-
 //go:generate gojson -o changes.go -name "Changes" -pkg "gophernews" -input json/updates.json
 
-//go:generate gojson -o comment.go -name "Comment" -pkg "gophernews" -input json/2921983.json
-
-//go:generate gojson -o poll.go -name "Poll" -pkg "gophernews" -input json/126809.json
-
-//go:generate gojson -o story.go -name "Story" -pkg "gophernews" -input json/8863.json
-
-//go:generate gojson -o part.go -name "Part" -pkg "gophernews" -input json/160705.json
-
-// Initializes and returns an API client
-func NewClient() *Client {
+// Initializes and returns an API client, configured by any options given
+func NewClient(opts ...Option) *Client {
 	var c Client
 	c.BaseURI = "https://hacker-news.firebaseio.com/"
 	c.Version = "v0"
 	c.Suffix = ".json"
+	for _, opt := range opts {
+		opt(&c)
+	}
 	return &c
 }
 
 // Makes an API request and puts response into a Story struct
 func (c *Client) GetStory(id int) (Story, error) {
-	item, err := c.GetItem(id)
+	return c.GetStoryContext(context.Background(), id)
+}
 
+// Same as GetStory, but the request is bound to ctx
+func (c *Client) GetStoryContext(ctx context.Context, id int) (Story, error) {
+	item, err := c.getItemOfType(ctx, id, "story")
 	if err != nil {
 		return Story{}, err
 	}
 
-	if item.Type() != "story" {
-		emptyStory := Story{}
-		return emptyStory, fmt.Errorf("Called GetStory on ID #%v which is not a _story_. "+
-			"Item is of type _%v_.", id, item.Type)
-	} else {
-		story := item.ToStory()
-		return story, nil
-	}
+	return item.ToStory()
 }
 
 // Makes an API request and puts response into a Comment struct
 func (c *Client) GetComment(id int) (Comment, error) {
-	item, err := c.GetItem(id)
+	return c.GetCommentContext(context.Background(), id)
+}
 
+// Same as GetComment, but the request is bound to ctx
+func (c *Client) GetCommentContext(ctx context.Context, id int) (Comment, error) {
+	item, err := c.getItemOfType(ctx, id, "comment")
 	if err != nil {
 		return Comment{}, err
 	}
 
-	if item.Type() != "comment" {
-		emptyComment := Comment{}
-		return emptyComment, fmt.Errorf("Called GetComment on ID #%v which is not a _comment_. "+
-			"Item is of type _%v_.", id, item.Type)
-	} else {
-		comment := item.ToComment()
-		return comment, nil
-	}
+	return item.ToComment()
 }
 
 // Makes an API request and puts response into a Poll struct
 func (c *Client) GetPoll(id int) (Poll, error) {
-	item, err := c.GetItem(id)
+	return c.GetPollContext(context.Background(), id)
+}
 
+// Same as GetPoll, but the request is bound to ctx
+func (c *Client) GetPollContext(ctx context.Context, id int) (Poll, error) {
+	item, err := c.getItemOfType(ctx, id, "poll")
 	if err != nil {
 		return Poll{}, err
 	}
 
-	if item.Type() != "poll" {
-		emptyPoll := Poll{}
-		return emptyPoll, fmt.Errorf("Called GetPoll on ID #%v which is not a _poll_. "+
-			"Item is of type _%v_.", id, item.Type)
-	} else {
-		poll := item.ToPoll()
-		return poll, nil
-	}
+	return item.ToPoll()
 }
 
 // Makes an API request and puts response into a Part struct
 func (c *Client) GetPart(id int) (Part, error) {
-	item, err := c.GetItem(id)
+	return c.GetPartContext(context.Background(), id)
+}
 
+// Same as GetPart, but the request is bound to ctx
+func (c *Client) GetPartContext(ctx context.Context, id int) (Part, error) {
+	item, err := c.getItemOfType(ctx, id, "pollopt")
 	if err != nil {
 		return Part{}, err
 	}
 
-	if item.Type() != "pollopt" {
-		emptyPart := Part{}
-		return emptyPart, fmt.Errorf("Called GetPart on ID #%v which is not a _part_. "+
-			"Item is of type _%v_.", id, item.Type)
-	} else {
-		part := item.ToPart()
-		return part, nil
+	return item.ToPart()
+}
+
+// Makes an API request and puts response into a Job struct
+func (c *Client) GetJob(id int) (Job, error) {
+	return c.GetJobContext(context.Background(), id)
+}
+
+// Same as GetJob, but the request is bound to ctx
+func (c *Client) GetJobContext(ctx context.Context, id int) (Job, error) {
+	item, err := c.getItemOfType(ctx, id, "job")
+	if err != nil {
+		return Job{}, err
 	}
+
+	return item.ToJob()
 }
 
 // Makes an API request and puts response into a User struct
 func (c *Client) GetUser(id string) (User, error) {
-	// TODO - refactor URL call into separate method
-	url := c.BaseURI + c.Version + "/user/" + id + c.Suffix
+	return c.GetUserContext(context.Background(), id)
+}
 
+// Same as GetUser, but the request is bound to ctx
+func (c *Client) GetUserContext(ctx context.Context, id string) (User, error) {
 	var u User
 
-	body, err := c.MakeHTTPRequest(url)
-	if err != nil {
-		return u, err
-	}
-
-	err = json.Unmarshal(body, &u)
-	if err != nil {
-		return u, err
-	}
+	err := c.getJSON(ctx, "user/"+id, &u)
 
-	// TODO - other checking around errors (wrong type, nonexistent user, etc.)
-	return u, nil
+	return u, err
 }
 
 // Makes an API request and puts response into a item struct
-// items are then converted into Stories, Comments, Polls, and Parts (of polls)
+// items are then converted into Stories, Comments, Polls, Parts (of polls), and Jobs
 func (c *Client) GetItem(id int) (item, error) {
-	url := c.BaseURI + c.Version + "/item/" + strconv.Itoa(id) + c.Suffix
+	return c.GetItemContext(context.Background(), id)
+}
+
+// Same as GetItem, but the request is bound to ctx
+func (c *Client) GetItemContext(ctx context.Context, id int) (item, error) {
+	var i item
 
-	var i map[string]interface{}
+	err := c.getJSON(ctx, "item/"+strconv.Itoa(id), &i)
 
-	body, err := c.MakeHTTPRequest(url)
+	return i, err
+}
+
+// Fetches an item and makes sure it is of the expected type and, when the
+// Client asks for it, neither deleted nor dead
+func (c *Client) getItemOfType(ctx context.Context, id int, expected string) (item, error) {
+	i, err := c.GetItemContext(ctx, id)
 	if err != nil {
-		return i, err
+		return item{}, err
 	}
 
-	if string(body) == "404 page not found" {
-		return i, fmt.Errorf("404 page not found")
+	if i.Type() != expected {
+		return item{}, &TypeMismatchError{ID: id, Expected: expected, Actual: i.Type()}
 	}
 
-	err = json.Unmarshal(body, &i)
+	if err := c.checkSkipped(id, i); err != nil {
+		return item{}, err
+	}
 
-	return i, err
+	return i, nil
 }
 
-func (c *Client) GetTop100() ([]int, error) {
-	url := c.BaseURI + c.Version + "/topstories" + c.Suffix
-
-	body, err := c.MakeHTTPRequest(url)
-
-	var top100 []int
-
-	err = json.Unmarshal(body, &top100)
-	if err != nil {
-		return nil, err
+// Returns ErrDeleted or ErrDead when the Client skips items like i
+func (c *Client) checkSkipped(id int, i Item) error {
+	if c.SkipDeleted && i.Deleted() {
+		return fmt.Errorf("gophernews: item %d: %w", id, ErrDeleted)
 	}
 
-	if err != nil {
-		return nil, err
+	if c.SkipDead && i.Dead() {
+		return fmt.Errorf("gophernews: item %d: %w", id, ErrDead)
 	}
 
-	return top100, nil
+	return nil
 }
 
-func (c *Client) GetMaxItem() (Item, error) {
-	url := c.BaseURI + c.Version + "/maxitem" + c.Suffix
+// Deprecated: despite its name, GetTop100 returns every ID of the top
+// stories list (up to 500). Use GetTopStories.
+func (c *Client) GetTop100() ([]int, error) {
+	return c.GetTopStories(0)
+}
 
-	body, err := c.MakeHTTPRequest(url)
+// Deprecated: use GetTopStoriesContext.
+func (c *Client) GetTop100Context(ctx context.Context) ([]int, error) {
+	return c.GetTopStoriesContext(ctx, 0)
+}
 
-	var maxItemId int
+func (c *Client) GetMaxItem() (Item, error) {
+	return c.GetMaxItemContext(context.Background())
+}
 
-	err = json.Unmarshal(body, &maxItemId)
+// Same as GetMaxItem, but both requests are bound to ctx
+func (c *Client) GetMaxItemContext(ctx context.Context) (Item, error) {
+	maxItemId, err := c.getMaxItemID(ctx)
 	if err != nil {
 		return item{}, err
 	}
 
-	maxItem, err := c.GetItem(maxItemId)
+	maxItem, err := c.GetItemContext(ctx, maxItemId)
 
 	return maxItem, err
 }
 
-func (c *Client) GetChanges() (Changes, error) {
-	url := c.BaseURI + c.Version + "/updates" + c.Suffix
+// Makes an API request for the largest item ID
+func (c *Client) getMaxItemID(ctx context.Context) (int, error) {
+	var maxItemId int
+
+	err := c.getJSON(ctx, "maxitem", &maxItemId)
 
-	body, err := c.MakeHTTPRequest(url)
+	return maxItemId, err
+}
 
+func (c *Client) GetChanges() (Changes, error) {
+	return c.GetChangesContext(context.Background())
+}
+
+// Same as GetChanges, but the request is bound to ctx
+func (c *Client) GetChangesContext(ctx context.Context) (Changes, error) {
 	var changes Changes
 
-	err = json.Unmarshal(body, &changes)
+	err := c.getJSON(ctx, "updates", &changes)
 
 	return changes, err
 }
 
 func (c *Client) MakeHTTPRequest(url string) ([]byte, error) {
-	response, err := http.Get(url)
+	return c.MakeHTTPRequestContext(context.Background(), url)
+}
+
+// Same as MakeHTTPRequest, but the request is canceled when ctx is done
+// or its deadline passes. Transient failures are retried according to the
+// Client's RetryPolicy, honoring any Retry-After the server sends.
+func (c *Client) MakeHTTPRequestContext(ctx context.Context, url string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		body, err := c.doHTTPRequest(ctx, url)
+		if err == nil {
+			return body, nil
+		}
+
+		if !c.Retry.shouldRetry(attempt, err) {
+			return nil, err
+		}
+
+		wait := c.Retry.Backoff(attempt)
+		var he *HTTPError
+		if errors.As(err, &he) && he.RetryAfter > 0 {
+			wait = he.RetryAfter
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Makes a single attempt at an API request
+func (c *Client) doHTTPRequest(ctx context.Context, url string) ([]byte, error) {
+	if err := c.waitForLimiter(ctx); err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.UserAgent != "" {
+		request.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	response, err := c.httpClient().Do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -214,16 +296,84 @@ func (c *Client) MakeHTTPRequest(url string) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	if response.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf(http.StatusText(http.StatusNotFound))
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, &HTTPError{
+			URL:        url,
+			StatusCode: response.StatusCode,
+			Body:       body,
+			RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 	return body, nil
 }
 
+// Makes an API request for path, unless the response is cached, and
+// decodes the JSON response into v. The API answers "null" with a 200 for
+// IDs and usernames that don't exist, which is reported as ErrNotFound
+// rather than leaving v empty.
+func (c *Client) getJSON(ctx context.Context, path string, v interface{}) error {
+	url := c.url(path)
+
+	body, cached := c.cacheGet(ctx, path)
+	if !cached {
+		var err error
+		body, err = c.MakeHTTPRequestContext(ctx, url)
+		if err != nil {
+			return err
+		}
+	}
+
+	if isNull(body) {
+		return fmt.Errorf("gophernews: GET %s: %w", url, ErrNotFound)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return &DecodeError{URL: url, Body: body, Err: err}
+	}
+
+	if !cached {
+		c.cacheSet(path, body)
+	}
+
+	return nil
+}
+
+// Reports whether a response body is the JSON literal null
+func isNull(body []byte) bool {
+	return string(bytes.TrimSpace(body)) == "null"
+}
+
+// Returns the configured HTTP client, falling back to http.DefaultClient
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// Builds the full URL for an API path such as "item/8863" or "topstories"
+func (c *Client) url(path string) string {
+	return c.BaseURI + c.Version + "/" + path + c.Suffix
+}
+
+// Returns a *TypeMismatchError unless i is of the expected type
+func (i item) checkType(expected string) error {
+	if i.Type() != expected {
+		return &TypeMismatchError{ID: i.ID(), Expected: expected, Actual: i.Type()}
+	}
+	return nil
+}
+
 // Convert an item to a Story
-func (i item) ToStory() Story {
+func (i item) ToStory() (Story, error) {
 	var s Story
+	if err := i.checkType("story"); err != nil {
+		return s, err
+	}
 	s.By = i.By()
+	s.Dead = i.Dead()
+	s.Deleted = i.Deleted()
+	s.Descendants = i.Descendants()
 	s.ID = i.ID()
 	s.Kids = i.Kids()
 	s.Score = i.Score()
@@ -231,26 +381,37 @@ func (i item) ToStory() Story {
 	s.Title = i.Title()
 	s.Type = i.Type()
 	s.URL = i.URL()
-	return s
+	return s, nil
 }
 
 // Convert an item to a Comment
-func (i item) ToComment() Comment {
+func (i item) ToComment() (Comment, error) {
 	var c Comment
+	if err := i.checkType("comment"); err != nil {
+		return c, err
+	}
 	c.By = i.By()
+	c.Dead = i.Dead()
+	c.Deleted = i.Deleted()
 	c.ID = i.ID()
 	c.Kids = i.Kids()
 	c.Parent = i.Parent()
 	c.Text = i.Text()
 	c.Time = i.Time()
 	c.Type = i.Type()
-	return c
+	return c, nil
 }
 
 // Convert an item to a Poll
-func (i item) ToPoll() Poll {
+func (i item) ToPoll() (Poll, error) {
 	var p Poll
+	if err := i.checkType("poll"); err != nil {
+		return p, err
+	}
 	p.By = i.By()
+	p.Dead = i.Dead()
+	p.Deleted = i.Deleted()
+	p.Descendants = i.Descendants()
 	p.ID = i.ID()
 	p.Kids = i.Kids()
 	p.Parts = i.Parts()
@@ -259,20 +420,45 @@ func (i item) ToPoll() Poll {
 	p.Time = i.Time()
 	p.Title = i.Title()
 	p.Type = i.Type()
-	return p
+	return p, nil
 }
 
 // Convert an item to a Part
-func (i item) ToPart() Part {
+func (i item) ToPart() (Part, error) {
 	var p Part
+	if err := i.checkType("pollopt"); err != nil {
+		return p, err
+	}
 	p.By = i.By()
+	p.Dead = i.Dead()
+	p.Deleted = i.Deleted()
 	p.ID = i.ID()
 	p.Parent = i.Parent()
+	p.Poll = i.Poll()
 	p.Score = i.Score()
 	p.Text = i.Text()
 	p.Time = i.Time()
 	p.Type = i.Type()
-	return p
+	return p, nil
+}
+
+// Convert an item to a Job
+func (i item) ToJob() (Job, error) {
+	var j Job
+	if err := i.checkType("job"); err != nil {
+		return j, err
+	}
+	j.By = i.By()
+	j.Dead = i.Dead()
+	j.Deleted = i.Deleted()
+	j.ID = i.ID()
+	j.Score = i.Score()
+	j.Text = i.Text()
+	j.Time = i.Time()
+	j.Title = i.Title()
+	j.Type = i.Type()
+	j.URL = i.URL()
+	return j, nil
 }
 
 func main() {
@@ -283,6 +469,7 @@ func main() {
 	// c, err := client.GetComment(2921983) //=> Actual Comment
 	// p, err := client.GetPoll(126809) //=> Actual Poll
 	// pp, err := client.GetPart(160705) //=> Actual Part of Poll
+	// j, err := client.GetJob(192327) //=> Actual Job
 	// u, err := client.GetUser("pg") //=> User
 
 	if err != nil {
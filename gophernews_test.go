@@ -3,6 +3,7 @@ package gophernews
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"testing"
@@ -92,6 +93,61 @@ func TestGetComment(t *testing.T) {
 	}
 }
 
+func TestGetJob(t *testing.T) {
+	setup()
+	defer teardown()
+
+	jsonJob, err := ioutil.ReadFile("json/192327.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Set up API stub
+	mux.HandleFunc("/v0/item/192327.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(jsonJob)
+	})
+
+	// Initialize a job with expected values
+	expected := Job{}
+
+	_ = json.Unmarshal(jsonJob, &expected)
+
+	// Test GetJob with an actual Job's ID
+	j, err := client.GetJob(192327)
+
+	// Makes sure an error wasn't passed
+	if err != nil {
+		t.Errorf("Error for client.GetJob(192327) should have been nil. Was: %v", err)
+	}
+
+	// Checks to make sure request equals expected value
+	if !reflect.DeepEqual(j, expected) {
+		t.Errorf("client.GetJob(192327) returned %+v, was expecting %+v", j, expected)
+	}
+
+	badResponse := `{
+    "by" : "dhouston",
+    "type" : "story"
+  }`
+
+	mux.HandleFunc("/v0/item/8863.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, badResponse)
+	})
+
+	// Test GetJob with an ID from a non-Job object
+	j, err = client.GetJob(8863)
+	// Makes sure an error was passed
+	if err == nil {
+		t.Errorf("Error for client.GetJob(8863) should not have been nil. Should have been a type error.")
+	}
+
+	// Checks to make sure method returns an empty Job object if the ID is bad
+	empty := Job{}
+	if !reflect.DeepEqual(j, empty) {
+		t.Errorf("client.GetJob(8863) returned %+v, should have been empty: %+v", j, empty)
+	}
+}
+
 func TestGetMax(t *testing.T) {
 	setup()
 	defer teardown()
@@ -238,7 +294,7 @@ func TestGetStory(t *testing.T) {
 	defer teardown()
 
 	// Initialize a story with expected values
-	jsonStory := `{"by":"dhouston","id":8863,"kids":[8952,9224,8917,8884,8887,8943,8869,8958,9005,9671,8940,9067,8908,9055,8865,8881,8872,8873,8955,10403,8903,8928,9125,8998,8901,8902,8907,8894,8878,8870,8980,8934,8876],"score":111,"time":1175714200,"title":"My YC app: Dropbox - Throw away your USB drive","type":"story","url":"http://www.getdropbox.com/u/2/screencast.html"}`
+	jsonStory := `{"by":"dhouston","descendants":71,"id":8863,"kids":[8952,9224,8917,8884,8887,8943,8869,8958,9005,9671,8940,9067,8908,9055,8865,8881,8872,8873,8955,10403,8903,8928,9125,8998,8901,8902,8907,8894,8878,8870,8980,8934,8876],"score":111,"time":1175714200,"title":"My YC app: Dropbox - Throw away your USB drive","type":"story","url":"http://www.getdropbox.com/u/2/screencast.html"}`
 
 	// Set up API stub
 	mux.HandleFunc("/v0/item/8863.json", func(w http.ResponseWriter, r *http.Request) {
@@ -305,7 +361,7 @@ func TestGetTop100(t *testing.T) {
 
 	// Makes sure an error wasn't passed
 	if err != nil {
-		t.Errorf("Error when calling GetTop100:\n", err)
+		t.Errorf("Error when calling GetTop100:\n%v", err)
 	}
 
 	// Checks to make sure request equals expected value
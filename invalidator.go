@@ -0,0 +1,128 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Invalidation reports a cache entry evicted because /updates listed it
+type Invalidation struct {
+	// Key is the evicted cache key, e.g. "item/8863" or "user/pg"
+	Key string
+	// ItemID is set when an item changed
+	ItemID int
+	// User is set when a profile changed
+	User string
+	// At is when the change was seen
+	At time.Time
+}
+
+// InvalidatorOptions tunes an Invalidator. A nil *InvalidatorOptions uses the defaults.
+type InvalidatorOptions struct {
+	// Interval is the time between two polls of /updates; 30 seconds when zero
+	Interval time.Duration
+	// Buffer is the number of events Events holds for a slow reader; 256
+	// when zero. Events that don't fit are dropped, evictions never are.
+	Buffer int
+	// OnError is called when a poll fails; polling carries on regardless
+	OnError func(error)
+}
+
+// Invalidator keeps a Client's cache fresh by polling /updates and evicting
+// every item and profile it lists
+type Invalidator struct {
+	dropped int64 // first, so atomic operations see it 64-bit aligned on 32-bit platforms
+
+	client   *Client
+	interval time.Duration
+	onError  func(error)
+	events   chan Invalidation
+}
+
+// Returns an invalidator for the Client's cache. Nothing happens until Run is called.
+func (c *Client) NewInvalidator(opts *InvalidatorOptions) *Invalidator {
+	if opts == nil {
+		opts = &InvalidatorOptions{}
+	}
+
+	inv := &Invalidator{client: c, interval: opts.Interval, onError: opts.OnError}
+	if inv.interval <= 0 {
+		inv.interval = 30 * time.Second
+	}
+
+	buffer := opts.Buffer
+	if buffer <= 0 {
+		buffer = 256
+	}
+	inv.events = make(chan Invalidation, buffer)
+
+	return inv
+}
+
+// Run polls /updates right away and then every Interval until ctx is done.
+// It closes Events and returns the context's error when it stops.
+func (inv *Invalidator) Run(ctx context.Context) error {
+	defer close(inv.events)
+
+	if inv.client.Cache == nil {
+		return errors.New("gophernews: invalidator needs a client with a cache")
+	}
+
+	ticker := time.NewTicker(inv.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := inv.Poll(ctx); err != nil && ctx.Err() == nil && inv.onError != nil {
+			inv.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches /updates once and evicts every item and profile it lists
+func (inv *Invalidator) Poll(ctx context.Context) error {
+	changes, err := inv.client.GetChangesContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, id := range changes.Items {
+		inv.evict(Invalidation{Key: "item/" + strconv.Itoa(id), ItemID: id, At: now})
+	}
+
+	for _, user := range changes.Profiles {
+		inv.evict(Invalidation{Key: "user/" + user, User: user, At: now})
+	}
+
+	return nil
+}
+
+// Events returns the stream of evictions. It is closed when Run returns.
+func (inv *Invalidator) Events() <-chan Invalidation {
+	return inv.events
+}
+
+// Dropped returns the number of events dropped because Events was full
+func (inv *Invalidator) Dropped() int64 {
+	return atomic.LoadInt64(&inv.dropped)
+}
+
+func (inv *Invalidator) evict(event Invalidation) {
+	inv.client.Cache.Delete(event.Key)
+
+	select {
+	case inv.events <- event:
+	default:
+		atomic.AddInt64(&inv.dropped, 1)
+	}
+}
@@ -0,0 +1,101 @@
+package gophernews
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInvalidator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var score int64 = 111
+
+	// Set up API stubs: a story whose score keeps going up, and an updates feed listing it
+	mux.HandleFunc("/v0/item/8863.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":8863,"score":%d,"type":"story"}`, atomic.AddInt64(&score, 1))
+	})
+	mux.HandleFunc("/v0/updates.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[8863,8952],"profiles":["pg"]}`)
+	})
+
+	cache := NewMemoryCache(100, 0)
+	client.Cache = cache
+
+	first, _ := client.GetStory(8863)
+	cached, _ := client.GetStory(8863)
+	if cached.Score != first.Score {
+		t.Fatalf("client.GetStory(8863) was not cached: score %d then %d", first.Score, cached.Score)
+	}
+
+	inv := client.NewInvalidator(&InvalidatorOptions{Interval: time.Hour, Buffer: 2})
+
+	if err := inv.Poll(context.Background()); err != nil {
+		t.Fatalf("Error for inv.Poll should have been nil. Was: %v", err)
+	}
+
+	// Makes sure every listed key is reported, in order
+	for _, key := range []string{"item/8863", "item/8952"} {
+		if event := <-inv.Events(); event.Key != key {
+			t.Errorf("Invalidated %q, was expecting %q", event.Key, key)
+		}
+	}
+
+	// The profile didn't fit in the buffer, but was still evicted
+	if inv.Dropped() != 1 {
+		t.Errorf("inv.Dropped() = %d, was expecting 1", inv.Dropped())
+	}
+
+	fresh, _ := client.GetStory(8863)
+	if fresh.Score == first.Score {
+		t.Errorf("client.GetStory(8863) returned the stale score %d after invalidation", fresh.Score)
+	}
+}
+
+func TestInvalidatorRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := make(chan bool, 10)
+
+	// Set up API stub
+	mux.HandleFunc("/v0/updates.json", func(w http.ResponseWriter, r *http.Request) {
+		polls <- true
+		fmt.Fprint(w, `{"items":[8863],"profiles":[]}`)
+	})
+
+	client.Cache = NewMemoryCache(100, 0)
+	inv := client.NewInvalidator(&InvalidatorOptions{Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- inv.Run(ctx) }()
+
+	// Makes sure /updates is polled on every tick
+	for n := 0; n < 3; n++ {
+		select {
+		case <-polls:
+		case <-time.After(time.Second):
+			t.Fatalf("Poll %d didn't happen within a second", n+1)
+		}
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("inv.Run returned %v, was expecting %v", err, context.Canceled)
+	}
+
+	// Makes sure Events is closed once Run returns
+	for range inv.Events() {
+	}
+
+	// Makes sure Run refuses to start without a cache
+	client.Cache = nil
+	if err := client.NewInvalidator(nil).Run(context.Background()); err == nil {
+		t.Errorf("Error for Run without a cache should not have been nil")
+	}
+}
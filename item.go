@@ -1,84 +1,213 @@
 package gophernews
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 type Item interface {
 	By() string
+	Dead() bool
+	Deleted() bool
+	Descendants() int
 	ID() int
 	Kids() []int
 	Parent() int
 	Parts() []int
+	Poll() int
 	Score() int
 	Text() string
-	Time() int
+	Time() Timestamp
 	Title() string
 	Type() string
 	URL() string
+	// Extra returns the raw JSON of the fields the API sent that Item has
+	// no accessor for, or nil if there were none
+	Extra() map[string]json.RawMessage
+}
+
+// item has varying fields, and not all fields will be returned for any single call.
+// Absent fields are left at their zero value.
+type item struct {
+	fields itemFields
+	extra  map[string]json.RawMessage
+}
+
+// itemFields holds every field the API defines for an item
+type itemFields struct {
+	By          string  `json:"by,omitempty"`
+	Dead        bool    `json:"dead,omitempty"`
+	Deleted     bool    `json:"deleted,omitempty"`
+	Descendants int     `json:"descendants,omitempty"`
+	ID          int64   `json:"id"`
+	Kids        []int64 `json:"kids,omitempty"`
+	Parent      int64   `json:"parent,omitempty"`
+	Parts       []int64 `json:"parts,omitempty"`
+	Poll        int64   `json:"poll,omitempty"`
+	Score       int     `json:"score,omitempty"`
+	Text        string  `json:"text,omitempty"`
+	Time        int64   `json:"time,omitempty"`
+	Title       string  `json:"title,omitempty"`
+	Type        string  `json:"type,omitempty"`
+	URL         string  `json:"url,omitempty"`
+}
+
+// The keys of itemFields
+var itemKeys = map[string]bool{
+	"by": true, "dead": true, "deleted": true, "descendants": true, "id": true,
+	"kids": true, "parent": true, "parts": true, "poll": true, "score": true,
+	"text": true, "time": true, "title": true, "type": true, "url": true,
+}
+
+// Decodes an item into its fields. Fields the API adds later are kept in
+// extra rather than dropped; that takes a second pass, made only when the
+// object has such fields.
+func (i *item) UnmarshalJSON(data []byte) error {
+	*i = item{}
+
+	if err := json.Unmarshal(data, &i.fields); err != nil {
+		return err
+	}
+
+	if !hasUnknownKeys(data) {
+		return nil
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for key, value := range all {
+		if !itemKeys[key] {
+			if i.extra == nil {
+				i.extra = make(map[string]json.RawMessage)
+			}
+			i.extra[key] = value
+		}
+	}
+
+	return nil
 }
 
-// item cannot be autogenerated as a struct, as it should not be represented as a struct
-// item has varying fields, and not all fields will be returned for any single call
-type item map[string]interface{}
+// Reports whether a valid JSON object has keys outside itemKeys, without
+// decoding it. Keys with escapes are reported as unknown.
+func hasUnknownKeys(data []byte) bool {
+	depth := 0
+	for n := 0; n < len(data); n++ {
+		switch data[n] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case '"':
+			start := n + 1
+			escaped := false
+			for n++; data[n] != '"'; n++ {
+				if data[n] == '\\' {
+					escaped = true
+					n++
+				}
+			}
+			if depth != 1 {
+				continue
+			}
+
+			// A string at the top level is a key when a colon follows it
+			rest := bytes.TrimLeft(data[n+1:], " \t\r\n")
+			if len(rest) > 0 && rest[0] == ':' && (escaped || !itemKeys[string(data[start:n])]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Encodes an item back to the API's JSON, unknown fields included
+func (i item) MarshalJSON() ([]byte, error) {
+	body, err := json.Marshal(i.fields)
+	if err != nil || len(i.extra) == 0 {
+		return body, err
+	}
+
+	extra, err := json.Marshal(i.extra)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both are objects: join them as {fields,extra}
+	body = append(body[:len(body)-1], ',')
+	return append(body, extra[1:]...), nil
+}
 
 func (i item) By() string {
-	s, _ := i["by"].(string)
-	return s
+	return i.fields.By
+}
+
+func (i item) Dead() bool {
+	return i.fields.Dead
+}
+
+func (i item) Deleted() bool {
+	return i.fields.Deleted
+}
+
+func (i item) Descendants() int {
+	return i.fields.Descendants
 }
 
 func (i item) ID() int {
-	s, _ := i["id"].(float64)
-	return int(s)
+	return int(i.fields.ID)
 }
 
 func (i item) Kids() []int {
-	kids, _ := i["kids"]
-	fsi, _ := kids.([]interface{})
-	is := make([]int, len(fsi))
-	for i, f := range fsi {
-		is[i] = int(f.(float64))
-	}
-	return is
+	return ints(i.fields.Kids)
 }
 
 func (i item) Parent() int {
-	s, _ := i["parent"].(float64)
-	return int(s)
+	return int(i.fields.Parent)
 }
 
 func (i item) Parts() []int {
-	parts, _ := i["parts"]
-	fsi, _ := parts.([]interface{})
-	is := make([]int, len(fsi))
-	for i, f := range fsi {
-		is[i] = int(f.(float64))
-	}
-	return is
+	return ints(i.fields.Parts)
+}
+
+func (i item) Poll() int {
+	return int(i.fields.Poll)
 }
 
 func (i item) Score() int {
-	s, _ := i["score"].(float64)
-	return int(s)
+	return i.fields.Score
 }
 
 func (i item) Text() string {
-	s, _ := i["text"].(string)
-	return s
+	return i.fields.Text
 }
 
-func (i item) Time() int {
-	s, _ := i["time"].(float64)
-	return int(s)
+func (i item) Time() Timestamp {
+	return UnixTimestamp(i.fields.Time)
 }
 
 func (i item) Title() string {
-	s, _ := i["title"].(string)
-	return s
+	return i.fields.Title
 }
 
 func (i item) Type() string {
-	s, _ := i["type"].(string)
-	return s
+	return i.fields.Type
 }
 
 func (i item) URL() string {
-	s, _ := i["url"].(string)
-	return s
+	return i.fields.URL
+}
+
+func (i item) Extra() map[string]json.RawMessage {
+	return i.extra
+}
+
+// Converts a list of IDs to ints, the type the rest of the package uses
+func ints(ids []int64) []int {
+	is := make([]int, len(ids))
+	for n, id := range ids {
+		is[n] = int(id)
+	}
+	return is
 }
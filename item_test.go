@@ -0,0 +1,83 @@
+package gophernews
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestItemDecode(t *testing.T) {
+	var i item
+	body := `{"by":"pg","id":2147483647,"kids":[2147483646,2],"type":"story","flagged":true,"ranks":[1,2],"meta":{"id":1}}`
+	if err := json.Unmarshal([]byte(body), &i); err != nil {
+		t.Fatalf("Error for json.Unmarshal should have been nil. Was: %v", err)
+	}
+
+	if i.ID() != 2147483647 || i.By() != "pg" || i.Type() != "story" {
+		t.Errorf("Item decoded as %+v, was expecting story 2147483647 by pg", i.fields)
+	}
+	if expected := []int{2147483646, 2}; !reflect.DeepEqual(i.Kids(), expected) {
+		t.Errorf("Kids() returned %v, was expecting %v", i.Kids(), expected)
+	}
+
+	// Makes sure unknown fields are kept, but not the keys nested in them
+	extra := i.Extra()
+	if len(extra) != 3 || string(extra["flagged"]) != "true" || string(extra["ranks"]) != "[1,2]" || string(extra["meta"]) != `{"id":1}` {
+		t.Errorf("Extra() returned %s, was expecting flagged, ranks and meta", extra)
+	}
+
+	// Makes sure an item with known fields only has no extra
+	var known item
+	if err := json.Unmarshal([]byte(`{"id":1,"text":"a \"quoted\" key: like this","type":"comment"}`), &known); err != nil || known.Extra() != nil {
+		t.Errorf("Extra() returned %s, %v, was expecting nil", known.Extra(), err)
+	}
+}
+
+func TestItemMarshal(t *testing.T) {
+	var i item
+	body := `{"by":"pg","id":1,"type":"story","flagged":true}`
+	if err := json.Unmarshal([]byte(body), &i); err != nil {
+		t.Fatalf("Error for json.Unmarshal should have been nil. Was: %v", err)
+	}
+
+	encoded, err := json.Marshal(i)
+	if err != nil {
+		t.Fatalf("Error for json.Marshal should have been nil. Was: %v", err)
+	}
+
+	// Makes sure the encoded item decodes back to the same item
+	var again item
+	if err := json.Unmarshal(encoded, &again); err != nil || !reflect.DeepEqual(again, i) {
+		t.Errorf("json.Marshal returned %s, which decodes to %+v, was expecting %+v", encoded, again, i)
+	}
+}
+
+func TestItemBadField(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"type":"story","kids":[2,"three"]}`)
+	})
+
+	var de *DecodeError
+	if _, err := client.GetItem(1); !errors.As(err, &de) {
+		t.Errorf("client.GetItem(1) returned %v, was expecting a *DecodeError", err)
+	}
+}
+
+func TestItemConversions(t *testing.T) {
+	i := item{fields: itemFields{ID: 2921983, Type: "comment"}}
+
+	var tm *TypeMismatchError
+	if _, err := i.ToStory(); !errors.As(err, &tm) || tm.ID != 2921983 || tm.Expected != "story" || tm.Actual != "comment" {
+		t.Errorf("ToStory() returned %v, was expecting a *TypeMismatchError", err)
+	}
+
+	if c, err := i.ToComment(); err != nil || c.ID != 2921983 {
+		t.Errorf("ToComment() returned %+v, %v, was expecting comment 2921983", c, err)
+	}
+}
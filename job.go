@@ -0,0 +1,16 @@
+package gophernews
+
+// Job was first generated by gojson from json/192327.json, and is now
+// maintained by hand
+type Job struct {
+	By      string    `json:"by"`
+	Dead    bool      `json:"dead"`
+	Deleted bool      `json:"deleted"`
+	ID      int       `json:"id"`
+	Score   int       `json:"score"`
+	Text    string    `json:"text"`
+	Time    Timestamp `json:"time"`
+	Title   string    `json:"title"`
+	Type    string    `json:"type"`
+	URL     string    `json:"url"`
+}
@@ -0,0 +1,74 @@
+package gophernews
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Client in NewClient
+type Option func(*Client)
+
+// WithHTTPClient makes the Client send every request through hc
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithBaseURL points the Client at another API host, e.g. a mirror or a test server
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.BaseURI = baseURL
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithTimeout limits the time spent on a single request, including reading the body
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		hc := c.ownHTTPClient()
+		hc.Timeout = timeout
+	}
+}
+
+// WithTransport sends every request through rt, e.g. a proxy, mTLS or test round-tripper
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		hc := c.ownHTTPClient()
+		hc.Transport = rt
+	}
+}
+
+// WithSkipDeleted makes the typed getters return ErrDeleted instead of
+// deleted items, which carry little more than an ID
+func WithSkipDeleted() Option {
+	return func(c *Client) {
+		c.SkipDeleted = true
+	}
+}
+
+// WithSkipDead makes the typed getters return ErrDead instead of dead
+// (flagged or killed) items
+func WithSkipDead() Option {
+	return func(c *Client) {
+		c.SkipDead = true
+	}
+}
+
+// Replaces the Client's HTTP client with a copy that options can modify
+// without touching http.DefaultClient or a client passed to WithHTTPClient
+func (c *Client) ownHTTPClient() *http.Client {
+	hc := *c.httpClient()
+	c.HTTPClient = &hc
+	return c.HTTPClient
+}
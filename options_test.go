@@ -0,0 +1,86 @@
+package gophernews
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNewClientOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stub
+	mux.HandleFunc("/v0/maxitem.json", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != "gophernews-test" {
+			t.Errorf("Request User-Agent = %q, want %q", got, "gophernews-test")
+		}
+		fmt.Fprint(w, 8435557)
+	})
+
+	c := NewClient(WithBaseURL(server.URL), WithUserAgent("gophernews-test"), WithTimeout(time.Second))
+
+	if c.BaseURI != server.URL+"/" {
+		t.Errorf("WithBaseURL(%q) set BaseURI to %q", server.URL, c.BaseURI)
+	}
+
+	if c.HTTPClient == nil || c.HTTPClient.Timeout != time.Second {
+		t.Errorf("WithTimeout(time.Second) set HTTPClient to %+v", c.HTTPClient)
+	}
+
+	// Makes sure options never modify the global client
+	if http.DefaultClient.Timeout != 0 {
+		t.Errorf("WithTimeout modified http.DefaultClient: %+v", http.DefaultClient)
+	}
+
+	body, err := c.MakeHTTPRequest(c.url("maxitem"))
+	if err != nil {
+		t.Errorf("Error for c.MakeHTTPRequest should have been nil. Was: %v", err)
+	}
+	if string(body) != "8435557" {
+		t.Errorf("c.MakeHTTPRequest returned %q, was expecting %q", body, "8435557")
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	var requested string
+
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = r.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`[8863]`)),
+			Header:     make(http.Header),
+			Request:    r,
+		}, nil
+	})
+
+	hc := &http.Client{}
+	c := NewClient(WithHTTPClient(hc), WithTransport(rt))
+
+	// Makes sure the client passed to WithHTTPClient is left untouched
+	if hc.Transport != nil {
+		t.Errorf("WithTransport modified the client passed to WithHTTPClient")
+	}
+
+	top, err := c.GetTop100()
+	if err != nil {
+		t.Errorf("Error for c.GetTop100() should have been nil. Was: %v", err)
+	}
+	if len(top) != 1 || top[0] != 8863 {
+		t.Errorf("c.GetTop100() returned %v, was expecting [8863]", top)
+	}
+
+	if requested != "https://hacker-news.firebaseio.com/v0/topstories.json" {
+		t.Errorf("Request went to %q through the custom transport", requested)
+	}
+}
@@ -1,11 +1,16 @@
 package gophernews
 
+// Part was first generated by gojson from json/160705.json, and is now
+// maintained by hand
 type Part struct {
-	By     string `json:"by"`
-	ID     int    `json:"id"`
-	Parent int    `json:"parent"`
-	Score  int    `json:"score"`
-	Text   string `json:"text"`
-	Time   int    `json:"time"`
-	Type   string `json:"type"`
+	By      string    `json:"by"`
+	Dead    bool      `json:"dead"`
+	Deleted bool      `json:"deleted"`
+	ID      int       `json:"id"`
+	Parent  int       `json:"parent"`
+	Poll    int       `json:"poll"`
+	Score   int       `json:"score"`
+	Text    string    `json:"text"`
+	Time    Timestamp `json:"time"`
+	Type    string    `json:"type"`
 }
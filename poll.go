@@ -1,13 +1,18 @@
 package gophernews
 
+// Poll was first generated by gojson from json/126809.json, and is now
+// maintained by hand
 type Poll struct {
-	By    string `json:"by"`
-	ID    int    `json:"id"`
-	Kids  []int  `json:"kids"`
-	Parts []int  `json:"parts"`
-	Score int    `json:"score"`
-	Text  string `json:"text"`
-	Time  int    `json:"time"`
-	Title string `json:"title"`
-	Type  string `json:"type"`
+	By          string    `json:"by"`
+	Dead        bool      `json:"dead"`
+	Deleted     bool      `json:"deleted"`
+	Descendants int       `json:"descendants"`
+	ID          int       `json:"id"`
+	Kids        []int     `json:"kids"`
+	Parts       []int     `json:"parts"`
+	Score       int       `json:"score"`
+	Text        string    `json:"text"`
+	Time        Timestamp `json:"time"`
+	Title       string    `json:"title"`
+	Type        string    `json:"type"`
 }
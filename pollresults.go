@@ -0,0 +1,68 @@
+package gophernews
+
+import "context"
+
+// PollResults is a Poll together with its parts and their vote tallies
+type PollResults struct {
+	Poll Poll
+	// Options are the poll's parts, in display order
+	Options []PollOption
+	// TotalVotes is the sum of the scores of every option
+	TotalVotes int
+	// Leader is the option with the most votes, the first one on a tie.
+	// It is nil when no option has any votes.
+	Leader *PollOption
+}
+
+// PollOption is a Part with its share of the poll's votes
+type PollOption struct {
+	Part Part
+	// Share is the fraction of the poll's votes, between 0 and 1
+	Share float64
+}
+
+// Makes an API request for a poll, then fetches all of its parts at once
+// and tallies their votes
+func (c *Client) GetPollWithParts(id int) (PollResults, error) {
+	return c.GetPollWithPartsContext(context.Background(), id)
+}
+
+// Same as GetPollWithParts, but the requests are bound to ctx
+func (c *Client) GetPollWithPartsContext(ctx context.Context, id int) (PollResults, error) {
+	poll, err := c.GetPollContext(ctx, id)
+	if err != nil {
+		return PollResults{}, err
+	}
+
+	results, err := c.GetItems(ctx, poll.Parts, nil)
+	if err != nil {
+		return PollResults{}, err
+	}
+
+	pr := PollResults{Poll: poll, Options: make([]PollOption, len(results))}
+
+	for n, r := range results {
+		if r.Err != nil {
+			return PollResults{}, r.Err
+		}
+		part, err := r.Item.(item).ToPart()
+		if err != nil {
+			return PollResults{}, err
+		}
+
+		pr.Options[n].Part = part
+		pr.TotalVotes += pr.Options[n].Part.Score
+	}
+
+	for n := range pr.Options {
+		option := &pr.Options[n]
+		if pr.TotalVotes > 0 {
+			option.Share = float64(option.Part.Score) / float64(pr.TotalVotes)
+		}
+		if option.Part.Score > 0 && (pr.Leader == nil || option.Part.Score > pr.Leader.Part.Score) {
+			pr.Leader = option
+		}
+	}
+
+	return pr, nil
+}
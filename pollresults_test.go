@@ -0,0 +1,42 @@
+package gophernews
+
+import "testing"
+
+func TestGetPollWithParts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stubs for a poll and its three parts
+	items := map[int]string{
+		126809: `{"by":"pg","id":126809,"parts":[126810,126811,126812],"score":46,"title":"Poll: What would happen if News.YC had explicit support for polls?","type":"poll"}`,
+		126810: `{"by":"pg","id":126810,"parent":126809,"score":335,"text":"Yes, ban them; I'm tired of seeing Valleywag stories on News.YC.","type":"pollopt"}`,
+		126811: `{"by":"pg","id":126811,"parent":126809,"score":110,"text":"No, don't ban them; I'll just not read them.","type":"pollopt"}`,
+		126812: `{"by":"pg","id":126812,"parent":126809,"score":55,"text":"No opinion.","type":"pollopt"}`,
+	}
+	handleItems(items)
+
+	pr, err := client.GetPollWithParts(126809)
+	if err != nil {
+		t.Fatalf("Error for client.GetPollWithParts(126809) should have been nil. Was: %v", err)
+	}
+
+	// Makes sure the parts are in display order
+	for n, id := range []int{126810, 126811, 126812} {
+		if pr.Options[n].Part.ID != id {
+			t.Errorf("Option %d of poll 126809 is part %d, was expecting %d", n, pr.Options[n].Part.ID, id)
+		}
+	}
+
+	// Checks the tallies
+	if pr.TotalVotes != 500 {
+		t.Errorf("Poll 126809 has %d votes, was expecting 500", pr.TotalVotes)
+	}
+	for n, share := range []float64{0.67, 0.22, 0.11} {
+		if pr.Options[n].Share != share {
+			t.Errorf("Option %d of poll 126809 has a share of %v, was expecting %v", n, pr.Options[n].Share, share)
+		}
+	}
+	if pr.Leader != &pr.Options[0] {
+		t.Errorf("Poll 126809 is led by %+v, was expecting part 126810", pr.Leader)
+	}
+}
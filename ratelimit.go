@@ -0,0 +1,152 @@
+package gophernews
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every goroutine using a Client.
+// Tokens refill at a steady rate up to a burst size; each request takes one.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	stats  LimiterStats
+}
+
+// LimiterStats reports how much a RateLimiter has throttled its callers
+type LimiterStats struct {
+	// Requests is the number of requests that went through the limiter
+	Requests int64
+	// Throttled is the number of requests that had to wait for a token
+	Throttled int64
+	// TotalWait is the time spent waiting, summed over all requests
+	TotalWait time.Duration
+}
+
+// Returns a limiter allowing requestsPerSecond on average and bursts of up
+// to burst requests. The bucket starts full. A requestsPerSecond of zero or
+// less never throttles.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// WithRateLimit makes every request of the Client wait for a token from a
+// limiter allowing requestsPerSecond with bursts of burst requests
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return WithRateLimiter(NewRateLimiter(requestsPerSecond, burst))
+}
+
+// WithRateLimiter makes every request of the Client wait for a token from l.
+// Share l between Clients to throttle them together.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(c *Client) {
+		c.Limiter = l
+	}
+}
+
+type rateLimitWaitKey struct{}
+
+// WithRateLimitWait returns a context whose requests report to fn how long
+// each of them waited for the Client's limiter, 0 when it didn't have to
+// or the Client has none. fn is called once per attempt, retries and
+// stream reconnections included, and may be called concurrently when ctx
+// is shared by several goroutines. Limiter.Stats only has the totals.
+func WithRateLimitWait(ctx context.Context, fn func(wait time.Duration)) context.Context {
+	return context.WithValue(ctx, rateLimitWaitKey{}, fn)
+}
+
+// Waits for the Client's limiter and reports the wait to the function
+// given to WithRateLimitWait, if any
+func (c *Client) waitForLimiter(ctx context.Context) error {
+	wait, err := c.Limiter.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	if fn, ok := ctx.Value(rateLimitWaitKey{}).(func(time.Duration)); ok && fn != nil {
+		fn(wait)
+	}
+	return nil
+}
+
+// Wait blocks until a token is available or ctx is done, and returns how
+// long the caller waited. A nil limiter never waits.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	if err := ctx.Err(); l == nil || err != nil {
+		return 0, err
+	}
+
+	wait, err := l.reserve(ctx)
+	if err != nil || wait == 0 {
+		return 0, err
+	}
+
+	if err := sleep(ctx, wait); err != nil {
+		l.cancel(wait)
+		return 0, err
+	}
+	return wait, nil
+}
+
+// Stats returns a snapshot of the limiter's counters
+func (l *RateLimiter) Stats() LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// Takes a token, possibly from the future, and returns how long the caller
+// must wait before it may use it
+func (l *RateLimiter) reserve(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.stats.Requests++
+	if l.rate <= 0 {
+		return 0, nil
+	}
+
+	var wait time.Duration
+	if l.tokens < 1 {
+		wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+			l.stats.Requests--
+			return 0, context.DeadlineExceeded
+		}
+	}
+
+	l.tokens--
+	if wait > 0 {
+		l.stats.Throttled++
+		l.stats.TotalWait += wait
+	}
+	return wait, nil
+}
+
+// Hands back a token reserved by a caller that gave up waiting
+func (l *RateLimiter) cancel(wait time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+	l.stats.Requests--
+	l.stats.Throttled--
+	l.stats.TotalWait -= wait
+}
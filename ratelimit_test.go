@@ -0,0 +1,120 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSharedAcrossGoroutines(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int64
+
+	// Set up API stub
+	mux.HandleFunc("/v0/maxitem.json", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+		fmt.Fprint(w, 8435557)
+	})
+
+	// 5 requests go through at once, the other 10 at 50 per second
+	client.Limiter = NewRateLimiter(50, 5)
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 15; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.MakeHTTPRequest(client.url("maxitem")); err != nil {
+				t.Errorf("Error for client.MakeHTTPRequest should have been nil. Was: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Makes sure the limiter spread the requests out
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("15 requests at 50/s with a burst of 5 took %v, was expecting about 200ms", elapsed)
+	}
+
+	stats := client.Limiter.Stats()
+	if stats.Requests != 15 || requests != 15 {
+		t.Errorf("Limiter saw %d requests and server %d, was expecting 15", stats.Requests, requests)
+	}
+	// A slow scheduler may let a token or two refill before every goroutine starts
+	if stats.Throttled < 8 || stats.Throttled > 10 {
+		t.Errorf("Limiter throttled %d requests, was expecting between 8 and 10", stats.Throttled)
+	}
+	if stats.TotalWait <= 0 {
+		t.Errorf("Limiter reported a total wait of %v, was expecting more than 0", stats.TotalWait)
+	}
+}
+
+func TestRateLimiterWaitDeadline(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+
+	// Takes the only token
+	if wait, err := l.Wait(context.Background()); wait != 0 || err != nil {
+		t.Errorf("l.Wait() = %v, %v, was expecting 0, nil", wait, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Makes sure a wait that would outlast the deadline fails fast
+	if _, err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("l.Wait(ctx) returned %v, was expecting %v", err, context.DeadlineExceeded)
+	}
+
+	if stats := l.Stats(); stats.Requests != 1 || stats.Throttled != 0 {
+		t.Errorf("l.Stats() = %+v, was expecting 1 request and no throttling", stats)
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stub
+	mux.HandleFunc("/v0/item/8863.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"by":"dhouston","id":8863,"type":"story"}`)
+	})
+
+	client.Limiter = NewRateLimiter(20, 1)
+
+	var mu sync.Mutex
+	var waits []time.Duration
+	ctx := WithRateLimitWait(context.Background(), func(wait time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		waits = append(waits, wait)
+	})
+
+	for n := 0; n < 2; n++ {
+		if _, err := client.GetStoryContext(ctx, 8863); err != nil {
+			t.Fatalf("Error for client.GetStoryContext should have been nil. Was: %v", err)
+		}
+	}
+
+	// Makes sure each request reports its own wait: none for the first,
+	// about one token's worth for the second
+	if len(waits) != 2 || waits[0] != 0 || waits[1] <= 0 {
+		t.Errorf("Waits reported were %v, was expecting 0 and then a wait", waits)
+	}
+
+	// Makes sure requests bound to other contexts don't report
+	if _, err := client.GetStory(8863); err != nil {
+		t.Fatalf("Error for client.GetStory should have been nil. Was: %v", err)
+	}
+	if len(waits) != 2 {
+		t.Errorf("%d waits were reported, was expecting 2", len(waits))
+	}
+}
@@ -0,0 +1,148 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Returns a policy that retries quickly enough for tests
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestRetryTransientFailures(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+
+	// Set up API stub that fails twice before answering
+	mux.HandleFunc("/v0/maxitem.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, 8435557)
+	})
+
+	client.Retry = testRetryPolicy()
+
+	body, err := client.MakeHTTPRequest(client.url("maxitem"))
+
+	// Makes sure the third attempt succeeded
+	if err != nil {
+		t.Errorf("Error for client.MakeHTTPRequest should have been nil after retries. Was: %v", err)
+	}
+	if string(body) != "8435557" {
+		t.Errorf("client.MakeHTTPRequest returned %q, was expecting %q", body, "8435557")
+	}
+	if attempts != 3 {
+		t.Errorf("client.MakeHTTPRequest made %d attempts, was expecting 3", attempts)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+
+	mux.HandleFunc("/v0/maxitem.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.NotFound(w, r)
+	})
+
+	client.Retry = testRetryPolicy()
+
+	// Makes sure retries stop at MaxAttempts
+	if _, err := client.MakeHTTPRequest(client.url("maxitem")); err == nil {
+		t.Errorf("Error for client.MakeHTTPRequest should not have been nil after %d failures", attempts)
+	}
+	if attempts != 3 {
+		t.Errorf("client.MakeHTTPRequest made %d attempts, was expecting 3", attempts)
+	}
+
+	// Makes sure a 404 is never retried
+	attempts = 0
+	if _, err := client.MakeHTTPRequest(client.url("item/1")); err == nil {
+		t.Errorf("Error for client.MakeHTTPRequest should not have been nil for a 404")
+	}
+	if attempts != 1 {
+		t.Errorf("client.MakeHTTPRequest made %d attempts for a 404, was expecting 1", attempts)
+	}
+}
+
+func TestRetryRespectsContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0/maxitem.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	client.Retry = testRetryPolicy()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Makes sure the hour-long Retry-After wait is cut short by the deadline
+	start := time.Now()
+	_, err := client.MakeHTTPRequestContext(ctx, client.url("maxitem"))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("client.MakeHTTPRequestContext returned %v, was expecting %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("client.MakeHTTPRequestContext waited %v past its deadline", elapsed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2, Jitter: 0.5}
+
+	cases := []struct {
+		attempt int
+		base    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{10, time.Second},
+	}
+
+	for _, c := range cases {
+		got := p.Backoff(c.attempt)
+		if got < c.base/2 || got > c.base*3/2 {
+			t.Errorf("Backoff(%d) = %v, was expecting %v ± 50%%", c.attempt, got, c.base)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2015, 1, 7, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"-1":                            0,
+		"Wed, 07 Jan 2015 12:00:30 GMT": 30 * time.Second,
+		"Wed, 07 Jan 2015 11:00:00 GMT": 0,
+		"soon":                          0,
+	}
+
+	for header, expected := range cases {
+		if got := parseRetryAfter(header, now); got != expected {
+			t.Errorf("parseRetryAfter(%q) = %v, was expecting %v", header, got, expected)
+		}
+	}
+}
@@ -1,12 +1,17 @@
 package gophernews
 
+// Story was first generated by gojson from json/8863.json, and is now
+// maintained by hand
 type Story struct {
-	By    string `json:"by"`
-	ID    int    `json:"id"`
-	Kids  []int  `json:"kids"`
-	Score int    `json:"score"`
-	Time  int    `json:"time"`
-	Title string `json:"title"`
-	Type  string `json:"type"`
-	URL   string `json:"url"`
+	By          string    `json:"by"`
+	Dead        bool      `json:"dead"`
+	Deleted     bool      `json:"deleted"`
+	Descendants int       `json:"descendants"`
+	ID          int       `json:"id"`
+	Kids        []int     `json:"kids"`
+	Score       int       `json:"score"`
+	Time        Timestamp `json:"time"`
+	Title       string    `json:"title"`
+	Type        string    `json:"type"`
+	URL         string    `json:"url"`
 }
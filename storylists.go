@@ -0,0 +1,122 @@
+package gophernews
+
+import (
+	"context"
+	"fmt"
+)
+
+// StoryListKind names one of the lists of story IDs the API publishes
+type StoryListKind int
+
+const (
+	TopStories StoryListKind = iota
+	NewStories
+	BestStories
+	AskStories
+	ShowStories
+	JobStories
+)
+
+var storyListPaths = map[StoryListKind]string{
+	TopStories:  "topstories",
+	NewStories:  "newstories",
+	BestStories: "beststories",
+	AskStories:  "askstories",
+	ShowStories: "showstories",
+	JobStories:  "jobstories",
+}
+
+// String returns the API endpoint of the list, e.g. "topstories"
+func (k StoryListKind) String() string {
+	if path, ok := storyListPaths[k]; ok {
+		return path
+	}
+	return fmt.Sprintf("StoryListKind(%d)", int(k))
+}
+
+// Makes an API request for a list of story IDs, ranked as on the site.
+// A limit above zero keeps only the first limit IDs.
+func (c *Client) GetStoryList(kind StoryListKind, limit int) ([]int, error) {
+	return c.GetStoryListContext(context.Background(), kind, limit)
+}
+
+// Same as GetStoryList, but the request is bound to ctx
+func (c *Client) GetStoryListContext(ctx context.Context, kind StoryListKind, limit int) ([]int, error) {
+	path, ok := storyListPaths[kind]
+	if !ok {
+		return nil, fmt.Errorf("gophernews: unknown story list %v", kind)
+	}
+
+	var ids []int
+
+	err := c.getJSON(ctx, path, &ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	return ids, nil
+}
+
+// Returns the IDs of up to 500 top stories
+func (c *Client) GetTopStories(limit int) ([]int, error) {
+	return c.GetStoryList(TopStories, limit)
+}
+
+// Same as GetTopStories, but the request is bound to ctx
+func (c *Client) GetTopStoriesContext(ctx context.Context, limit int) ([]int, error) {
+	return c.GetStoryListContext(ctx, TopStories, limit)
+}
+
+// Returns the IDs of up to 500 newest stories
+func (c *Client) GetNewStories(limit int) ([]int, error) {
+	return c.GetStoryList(NewStories, limit)
+}
+
+// Same as GetNewStories, but the request is bound to ctx
+func (c *Client) GetNewStoriesContext(ctx context.Context, limit int) ([]int, error) {
+	return c.GetStoryListContext(ctx, NewStories, limit)
+}
+
+// Returns the IDs of up to 500 best stories
+func (c *Client) GetBestStories(limit int) ([]int, error) {
+	return c.GetStoryList(BestStories, limit)
+}
+
+// Same as GetBestStories, but the request is bound to ctx
+func (c *Client) GetBestStoriesContext(ctx context.Context, limit int) ([]int, error) {
+	return c.GetStoryListContext(ctx, BestStories, limit)
+}
+
+// Returns the IDs of up to 200 latest Ask HN stories
+func (c *Client) GetAskStories(limit int) ([]int, error) {
+	return c.GetStoryList(AskStories, limit)
+}
+
+// Same as GetAskStories, but the request is bound to ctx
+func (c *Client) GetAskStoriesContext(ctx context.Context, limit int) ([]int, error) {
+	return c.GetStoryListContext(ctx, AskStories, limit)
+}
+
+// Returns the IDs of up to 200 latest Show HN stories
+func (c *Client) GetShowStories(limit int) ([]int, error) {
+	return c.GetStoryList(ShowStories, limit)
+}
+
+// Same as GetShowStories, but the request is bound to ctx
+func (c *Client) GetShowStoriesContext(ctx context.Context, limit int) ([]int, error) {
+	return c.GetStoryListContext(ctx, ShowStories, limit)
+}
+
+// Returns the IDs of up to 200 latest job stories
+func (c *Client) GetJobStories(limit int) ([]int, error) {
+	return c.GetStoryList(JobStories, limit)
+}
+
+// Same as GetJobStories, but the request is bound to ctx
+func (c *Client) GetJobStoriesContext(ctx context.Context, limit int) ([]int, error) {
+	return c.GetStoryListContext(ctx, JobStories, limit)
+}
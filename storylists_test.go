@@ -0,0 +1,64 @@
+package gophernews
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetStoryList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	kinds := []StoryListKind{TopStories, NewStories, BestStories, AskStories, ShowStories, JobStories}
+
+	// Set up an API stub for every list, each answering with different IDs
+	for n, kind := range kinds {
+		body := fmt.Sprintf("[%d,%d,%d]", n*10+1, n*10+2, n*10+3)
+		mux.HandleFunc("/v0/"+kind.String()+".json", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+	}
+
+	for n, kind := range kinds {
+		expected := []int{n*10 + 1, n*10 + 2, n*10 + 3}
+
+		ids, err := client.GetStoryList(kind, 0)
+		if err != nil {
+			t.Errorf("Error for client.GetStoryList(%v, 0) should have been nil. Was: %v", kind, err)
+		}
+		if !reflect.DeepEqual(ids, expected) {
+			t.Errorf("client.GetStoryList(%v, 0) returned %v, was expecting %v", kind, ids, expected)
+		}
+
+		// Makes sure the limit keeps the first IDs
+		ids, _ = client.GetStoryList(kind, 2)
+		if !reflect.DeepEqual(ids, expected[:2]) {
+			t.Errorf("client.GetStoryList(%v, 2) returned %v, was expecting %v", kind, ids, expected[:2])
+		}
+	}
+
+	// Checks the named accessors hit the right endpoints
+	accessors := []struct {
+		name string
+		get  func(int) ([]int, error)
+	}{
+		{"GetTopStories", client.GetTopStories},
+		{"GetNewStories", client.GetNewStories},
+		{"GetBestStories", client.GetBestStories},
+		{"GetAskStories", client.GetAskStories},
+		{"GetShowStories", client.GetShowStories},
+		{"GetJobStories", client.GetJobStories},
+	}
+	for n, a := range accessors {
+		ids, err := a.get(1)
+		if err != nil || len(ids) != 1 || ids[0] != n*10+1 {
+			t.Errorf("client.%s(1) returned %v, %v, was expecting [%d]", a.name, ids, err, n*10+1)
+		}
+	}
+
+	if _, err := client.GetStoryList(StoryListKind(42), 0); err == nil {
+		t.Errorf("Error for client.GetStoryList(StoryListKind(42), 0) should not have been nil")
+	}
+}
@@ -0,0 +1,309 @@
+package gophernews
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrStreamCanceled ends a Stream when Firebase cancels it, e.g. because
+// the location may no longer be read
+var ErrStreamCanceled = errors.New("gophernews: stream canceled by server")
+
+// ErrAuthRevoked ends a Stream when Firebase revokes its credentials
+var ErrAuthRevoked = errors.New("gophernews: stream auth revoked")
+
+// StreamEventType tells how a StreamEvent changes the data at its path
+type StreamEventType int
+
+const (
+	// StreamPut replaces the data at Path with Data
+	StreamPut StreamEventType = iota
+	// StreamPatch updates the children of Path listed in Data, leaving the others alone
+	StreamPatch
+)
+
+func (t StreamEventType) String() string {
+	switch t {
+	case StreamPut:
+		return "put"
+	case StreamPatch:
+		return "patch"
+	}
+	return fmt.Sprintf("StreamEventType(%d)", int(t))
+}
+
+// StreamEvent is a live update of the data below a streamed location
+type StreamEvent struct {
+	Type StreamEventType
+	// Path is relative to the streamed location; "/" is the location itself
+	Path string
+	// Data is the new JSON value at Path, null when it was removed
+	Data json.RawMessage
+}
+
+// Decode unmarshals Data into v, e.g. a []int for /v0/topstories or an
+// Item's fields for /v0/item/ID
+func (e StreamEvent) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// StreamOptions tunes a Stream. A nil *StreamOptions uses the defaults.
+type StreamOptions struct {
+	// IdleTimeout reconnects when nothing, not even a keep-alive, arrives
+	// for this long; 90 seconds when zero. Firebase sends a keep-alive
+	// every 30 seconds.
+	IdleTimeout time.Duration
+	// MaxReconnects gives up after this many failed connections in a row.
+	// Zero means no limit.
+	MaxReconnects int
+}
+
+// Stream is a live subscription to a location of the API, using the
+// EventSource protocol Firebase speaks when asked for text/event-stream
+type Stream struct {
+	client *Client
+	path   string
+	opts   StreamOptions
+	events chan StreamEvent
+	err    error
+
+	lastEventID string
+}
+
+// Subscribes to an API path such as "topstories" or "item/8863". The
+// stream reconnects after the failures the Client's RetryPolicy (or
+// DefaultRetryPolicy) finds retryable, backing off as it says. After each (re)connection
+// Firebase first sends a put of the whole location, which resumes the
+// caller from the current state.
+func (c *Client) Stream(ctx context.Context, path string, opts *StreamOptions) *Stream {
+	s := &Stream{client: c, path: path, events: make(chan StreamEvent)}
+	if opts != nil {
+		s.opts = *opts
+	}
+	if s.opts.IdleTimeout <= 0 {
+		s.opts.IdleTimeout = 90 * time.Second
+	}
+
+	go s.run(ctx)
+
+	return s
+}
+
+// Subscribes to a list of story IDs, e.g. TopStories
+func (c *Client) StreamStoryList(ctx context.Context, kind StoryListKind, opts *StreamOptions) *Stream {
+	return c.Stream(ctx, kind.String(), opts)
+}
+
+// Subscribes to an item
+func (c *Client) StreamItem(ctx context.Context, id int, opts *StreamOptions) *Stream {
+	return c.Stream(ctx, "item/"+strconv.Itoa(id), opts)
+}
+
+// Events returns the stream of updates. It is closed when the stream ends.
+func (s *Stream) Events() <-chan StreamEvent {
+	return s.events
+}
+
+// Err returns why the stream ended, once Events is closed
+func (s *Stream) Err() error {
+	return s.err
+}
+
+func (s *Stream) run(ctx context.Context) {
+	defer close(s.events)
+
+	policy := s.client.Retry
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+
+	failures := 0
+	for {
+		received, err := s.connect(ctx)
+		if ctx.Err() != nil {
+			s.err = ctx.Err()
+			return
+		}
+		if errors.Is(err, ErrStreamCanceled) || errors.Is(err, ErrAuthRevoked) || !policy.retryable(err) {
+			s.err = err
+			return
+		}
+
+		if received {
+			failures = 0
+		}
+		failures++
+		if s.opts.MaxReconnects > 0 && failures > s.opts.MaxReconnects {
+			s.err = err
+			return
+		}
+
+		if err := sleep(ctx, policy.Backoff(failures)); err != nil {
+			s.err = err
+			return
+		}
+	}
+}
+
+// errStreamIdle is returned by connect when the idle timeout fires
+var errStreamIdle = &streamIdleError{}
+
+type streamIdleError struct{}
+
+func (*streamIdleError) Error() string   { return "gophernews: stream idle for too long" }
+func (*streamIdleError) Timeout() bool   { return true }
+func (*streamIdleError) Temporary() bool { return true }
+
+// Opens one connection and forwards its events until it ends. Reports
+// whether any event arrived, and why the connection ended.
+func (s *Stream) connect(ctx context.Context) (bool, error) {
+	if err := s.client.waitForLimiter(ctx); err != nil {
+		return false, err
+	}
+
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var idle int32
+	timer := time.AfterFunc(s.opts.IdleTimeout, func() {
+		atomic.StoreInt32(&idle, 1)
+		cancel()
+	})
+	defer timer.Stop()
+
+	url := s.client.url(s.path)
+	request, err := http.NewRequestWithContext(connCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	if s.client.UserAgent != "" {
+		request.Header.Set("User-Agent", s.client.UserAgent)
+	}
+	if s.lastEventID != "" {
+		request.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+
+	// A stream outlives any request timeout of the configured client
+	hc := *s.client.httpClient()
+	hc.Timeout = 0
+
+	response, err := hc.Do(request)
+	if err != nil {
+		if atomic.LoadInt32(&idle) == 1 {
+			return false, errStreamIdle
+		}
+		return false, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(response.Body)
+		return false, &HTTPError{
+			URL:        url,
+			StatusCode: response.StatusCode,
+			Body:       body,
+			RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	received := false
+	reader := bufio.NewReader(response.Body)
+	var event, data, id string
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if atomic.LoadInt32(&idle) == 1 {
+				return received, errStreamIdle
+			}
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return received, err
+		}
+		timer.Reset(s.opts.IdleTimeout)
+
+		line = strings.TrimRight(line, "\r\n")
+		if line != "" {
+			field, value := parseSSELine(line)
+			switch field {
+			case "event":
+				event = value
+			case "data":
+				if data != "" {
+					data += "\n"
+				}
+				data += value
+			case "id":
+				id = value
+			}
+			continue
+		}
+
+		// A blank line dispatches the event read so far
+		if id != "" {
+			s.lastEventID = id
+		}
+
+		switch event {
+		case "put", "patch":
+			e, err := parseStreamEvent(event, data)
+			if err != nil {
+				return received, &DecodeError{URL: url, Body: []byte(data), Err: err}
+			}
+			select {
+			case <-ctx.Done():
+				return received, ctx.Err()
+			case s.events <- e:
+			}
+			received = true
+		case "keep-alive":
+		case "cancel":
+			return received, ErrStreamCanceled
+		case "auth_revoked":
+			return received, ErrAuthRevoked
+		}
+
+		event, data, id = "", "", ""
+	}
+}
+
+// Splits an EventSource line into its field and value. Comment lines,
+// starting with a colon, have no field.
+func parseSSELine(line string) (string, string) {
+	if strings.HasPrefix(line, ":") {
+		return "", ""
+	}
+	field, value, found := strings.Cut(line, ":")
+	if !found {
+		return field, ""
+	}
+	return field, strings.TrimPrefix(value, " ")
+}
+
+// Parses the data of a put or patch event: {"path": ..., "data": ...}
+func parseStreamEvent(event, data string) (StreamEvent, error) {
+	var payload struct {
+		Path string          `json:"path"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return StreamEvent{}, err
+	}
+
+	e := StreamEvent{Type: StreamPut, Path: payload.Path, Data: payload.Data}
+	if event == "patch" {
+		e.Type = StreamPatch
+	}
+	return e, nil
+}
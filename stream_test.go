@@ -0,0 +1,155 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Drains a stream, failing the test if it doesn't end within a second
+func drainStream(t *testing.T, s *Stream) []StreamEvent {
+	var events []StreamEvent
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case e, ok := <-s.Events():
+			if !ok {
+				return events
+			}
+			events = append(events, e)
+		case <-timeout:
+			t.Fatalf("Stream didn't end within a second, got %v", events)
+			return nil
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var connections int32
+
+	// Set up an API stub that drops the first connection and cancels the second
+	mux.HandleFunc("/v0/topstories.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("Request Accept = %q, want text/event-stream", r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch atomic.AddInt32(&connections, 1) {
+		case 1:
+			fmt.Fprint(w, "event: put\ndata: {\"path\":\"/\",\"data\":[8863,8952]}\n\n")
+			fmt.Fprint(w, ": a comment\n\nevent: keep-alive\ndata: null\n\n")
+			fmt.Fprint(w, "event: patch\r\ndata: {\"path\":\"/\",\"data\":{\"1\":9224}}\r\n\r\n")
+		case 2:
+			fmt.Fprint(w, "event: put\ndata: {\"path\":\"/\",\"data\":[8863,9224]}\n\n")
+			fmt.Fprint(w, "event: cancel\ndata: null\n\n")
+		}
+	})
+
+	client.Retry = testRetryPolicy()
+
+	s := client.StreamStoryList(context.Background(), TopStories, nil)
+	events := drainStream(t, s)
+
+	// Makes sure the stream reconnected and ended on the cancel event
+	if !errors.Is(s.Err(), ErrStreamCanceled) {
+		t.Errorf("s.Err() = %v, was expecting %v", s.Err(), ErrStreamCanceled)
+	}
+	if atomic.LoadInt32(&connections) != 2 {
+		t.Errorf("Stream connected %d times, was expecting 2", connections)
+	}
+
+	expected := []string{"put / [8863,8952]", `patch / {"1":9224}`, "put / [8863,9224]"}
+	var got []string
+	for _, e := range events {
+		got = append(got, fmt.Sprintf("%v %s %s", e.Type, e.Path, e.Data))
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Stream sent %q, was expecting %q", got, expected)
+	}
+
+	var ids []int
+	if err := events[2].Decode(&ids); err != nil || !reflect.DeepEqual(ids, []int{8863, 9224}) {
+		t.Errorf("events[2].Decode returned %v, %v, was expecting [8863 9224]", ids, err)
+	}
+}
+
+func TestStreamIdleTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var connections int32
+
+	// Set up an API stub that goes silent on the first connection
+	mux.HandleFunc("/v0/item/8863.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		if atomic.AddInt32(&connections, 1) == 1 {
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, "event: auth_revoked\ndata: credential is no longer valid\n\n")
+	})
+
+	client.Retry = testRetryPolicy()
+
+	s := client.StreamItem(context.Background(), 8863, &StreamOptions{IdleTimeout: 50 * time.Millisecond})
+	drainStream(t, s)
+
+	if !errors.Is(s.Err(), ErrAuthRevoked) {
+		t.Errorf("s.Err() = %v, was expecting %v", s.Err(), ErrAuthRevoked)
+	}
+	if atomic.LoadInt32(&connections) != 2 {
+		t.Errorf("Stream connected %d times, was expecting 2", connections)
+	}
+}
+
+func TestStreamGivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	s := client.StreamItem(context.Background(), 1, nil)
+	drainStream(t, s)
+
+	// Makes sure errors that aren't transient end the stream
+	if !errors.Is(s.Err(), ErrNotFound) {
+		t.Errorf("s.Err() = %v, was expecting %v", s.Err(), ErrNotFound)
+	}
+}
+
+func TestStreamRetryable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var connections int32
+
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&connections, 1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+
+	client.Retry = testRetryPolicy()
+	client.Retry.Retryable = func(err error) bool { return false }
+
+	s := client.StreamItem(context.Background(), 1, nil)
+	drainStream(t, s)
+
+	// Makes sure the policy's Retryable decides whether to reconnect
+	if s.Err() == nil {
+		t.Errorf("s.Err() should not have been nil")
+	}
+	if atomic.LoadInt32(&connections) != 1 {
+		t.Errorf("Stream connected %d times, was expecting 1", connections)
+	}
+}
@@ -0,0 +1,178 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// The number of submissions UserSubmissions resolves per batch when not told otherwise
+const defaultPageSize = 50
+
+// SubmissionFilter selects which of a user's submissions UserSubmissions
+// returns. A nil *SubmissionFilter returns every submission.
+type SubmissionFilter struct {
+	// Types keeps only items of these types, e.g. "story" or "comment".
+	// Every type is kept when empty.
+	Types []string
+	// Since keeps only items submitted at or after this time, when not zero
+	Since time.Time
+	// Until keeps only items submitted before this time, when not zero
+	Until time.Time
+	// MinScore keeps only items with at least this score. The API reports
+	// no score for comments, so any MinScore above zero drops them.
+	MinScore int
+	// PageSize is the number of items resolved per batch; 50 when zero
+	PageSize int
+	// Concurrency is the number of requests in flight at once; 8 when zero
+	Concurrency int
+}
+
+// Reports whether an item passes the filter
+func (f *SubmissionFilter) match(i Item) bool {
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if i.Type() == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	submitted := i.Time().Time
+	if !f.Since.IsZero() && submitted.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !submitted.Before(f.Until) {
+		return false
+	}
+
+	return i.Score() >= f.MinScore
+}
+
+// SubmissionIterator walks a user's submissions, newest first, fetching
+// them lazily a page at a time. Use it like a bufio.Scanner:
+//
+//	it := client.UserSubmissions(ctx, "pg", &gophernews.SubmissionFilter{Types: []string{"story"}})
+//	for it.Next() {
+//		fmt.Println(it.Item().Title())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type SubmissionIterator struct {
+	client   *Client
+	ctx      context.Context
+	username string
+	filter   SubmissionFilter
+
+	user    *User
+	ids     []int // submissions not fetched yet, newest first
+	page    []Item
+	current Item
+	done    bool
+	err     error
+}
+
+// Returns an iterator over the submissions of username that pass filter.
+// Nothing is fetched until the first call to Next.
+func (c *Client) UserSubmissions(ctx context.Context, username string, filter *SubmissionFilter) *SubmissionIterator {
+	it := &SubmissionIterator{client: c, ctx: ctx, username: username}
+	if filter != nil {
+		it.filter = *filter
+	}
+	if it.filter.PageSize <= 0 {
+		it.filter.PageSize = defaultPageSize
+	}
+	return it
+}
+
+// Next advances to the next matching submission and reports whether there
+// is one. It returns false at the end of the submissions or on an error.
+func (it *SubmissionIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			it.current = nil
+			return false
+		}
+		it.fetchPage()
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Item returns the submission Next advanced to
+func (it *SubmissionIterator) Item() Item {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SubmissionIterator) Err() error {
+	return it.err
+}
+
+// User returns the profile whose submissions are walked, once Next has
+// been called
+func (it *SubmissionIterator) User() *User {
+	return it.user
+}
+
+// Resolves the next page of submissions and keeps those passing the filter
+func (it *SubmissionIterator) fetchPage() {
+	if it.user == nil {
+		u, err := it.client.GetUserContext(it.ctx, it.username)
+		if err != nil {
+			it.err = err
+			return
+		}
+		it.user = &u
+
+		// IDs grow over time, so sorting them puts the newest first
+		it.ids = append([]int(nil), u.Submitted...)
+		sort.Sort(sort.Reverse(sort.IntSlice(it.ids)))
+	}
+
+	if len(it.ids) == 0 {
+		it.done = true
+		return
+	}
+
+	n := it.filter.PageSize
+	if n > len(it.ids) {
+		n = len(it.ids)
+	}
+	ids := it.ids[:n]
+	it.ids = it.ids[n:]
+
+	results, err := it.client.GetItems(it.ctx, ids, &BatchOptions{Concurrency: it.filter.Concurrency})
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	for _, r := range results {
+		if errors.Is(r.Err, ErrNotFound) {
+			continue
+		}
+		if r.Err != nil {
+			it.err = r.Err
+			return
+		}
+
+		// Everything after an item older than Since is older still
+		if !it.filter.Since.IsZero() && r.Item.Time().Before(it.filter.Since) {
+			it.done = true
+			return
+		}
+
+		if it.filter.match(r.Item) {
+			it.page = append(it.page, r.Item)
+		}
+	}
+}
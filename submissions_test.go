@@ -0,0 +1,111 @@
+package gophernews
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Sets up API stubs for a user with ten submissions: even IDs are stories
+// scoring 10 times their ID, odd IDs are comments, and item N was
+// submitted N hours after 2015-01-01. Returns a counter of item requests.
+func setupSubmissions() *int64 {
+	var requests int64
+
+	mux.HandleFunc("/v0/user/jl.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"jl","karma":2937,"submitted":[10,9,8,7,6,5,4,3,2,1]}`)
+	})
+
+	start := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	for id := 1; id <= 10; id++ {
+		submitted := start.Add(time.Duration(id) * time.Hour).Unix()
+		body := fmt.Sprintf(`{"by":"jl","id":%d,"parent":1,"time":%d,"type":"comment"}`, id, submitted)
+		if id%2 == 0 {
+			body = fmt.Sprintf(`{"by":"jl","id":%d,"score":%d,"time":%d,"type":"story"}`, id, id*10, submitted)
+		}
+		mux.HandleFunc(fmt.Sprintf("/v0/item/%d.json", id), func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt64(&requests, 1)
+			fmt.Fprint(w, body)
+		})
+	}
+
+	return &requests
+}
+
+// Drains an iterator into the IDs it returned
+func submissionIDs(t *testing.T, it *SubmissionIterator) []int {
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Item().ID())
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Error for the submissions iterator should have been nil. Was: %v", err)
+	}
+	return ids
+}
+
+func TestUserSubmissions(t *testing.T) {
+	setup()
+	defer teardown()
+	requests := setupSubmissions()
+
+	it := client.UserSubmissions(context.Background(), "jl", &SubmissionFilter{PageSize: 3})
+
+	// Makes sure nothing is fetched before the first call to Next
+	if *requests != 0 || it.User() != nil {
+		t.Errorf("client.UserSubmissions fetched %d items before Next was called", *requests)
+	}
+
+	// Makes sure items are resolved a page at a time
+	it.Next()
+	if n := atomic.LoadInt64(requests); n != 3 {
+		t.Errorf("The first call to Next fetched %d items, was expecting a page of 3", n)
+	}
+	if it.User() == nil || it.User().Karma != 2937 {
+		t.Errorf("it.User() returned %+v, was expecting jl's profile", it.User())
+	}
+
+	ids := append([]int{it.Item().ID()}, submissionIDs(t, it)...)
+	if fmt.Sprint(ids) != "[10 9 8 7 6 5 4 3 2 1]" {
+		t.Errorf("client.UserSubmissions returned %v, was expecting every submission newest first", ids)
+	}
+}
+
+func TestUserSubmissionsFilter(t *testing.T) {
+	setup()
+	defer teardown()
+	requests := setupSubmissions()
+
+	start := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		filter   SubmissionFilter
+		expected string
+	}{
+		{SubmissionFilter{Types: []string{"story"}}, "[10 8 6 4 2]"},
+		{SubmissionFilter{Types: []string{"comment"}}, "[9 7 5 3 1]"},
+		{SubmissionFilter{MinScore: 50}, "[10 8 6]"},
+		{SubmissionFilter{Until: start.Add(5 * time.Hour), Since: start.Add(2 * time.Hour)}, "[4 3 2]"},
+	}
+
+	for _, c := range cases {
+		ids := submissionIDs(t, client.UserSubmissions(context.Background(), "jl", &c.filter))
+		if fmt.Sprint(ids) != c.expected {
+			t.Errorf("client.UserSubmissions with %+v returned %v, was expecting %s", c.filter, ids, c.expected)
+		}
+	}
+
+	// Makes sure older submissions are not fetched once Since is passed
+	atomic.StoreInt64(requests, 0)
+	filter := &SubmissionFilter{Since: start.Add(8 * time.Hour), PageSize: 2}
+	ids := submissionIDs(t, client.UserSubmissions(context.Background(), "jl", filter))
+	if fmt.Sprint(ids) != "[10 9 8]" {
+		t.Errorf("client.UserSubmissions with %+v returned %v, was expecting [10 9 8]", filter, ids)
+	}
+	if n := atomic.LoadInt64(requests); n != 4 {
+		t.Errorf("client.UserSubmissions with %+v fetched %d items, was expecting 4", filter, n)
+	}
+}
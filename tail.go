@@ -0,0 +1,172 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TailOptions tunes TailNewItems. A nil *TailOptions uses the defaults.
+type TailOptions struct {
+	// After resumes the tail after this ID, e.g. a saved Checkpoint. When
+	// zero, the tail starts with the items created after it is started.
+	After int
+	// Interval is the time between two polls of /maxitem; 5 seconds when zero
+	Interval time.Duration
+	// BatchSize is the number of IDs fetched at once; 100 when zero
+	BatchSize int
+	// Concurrency is the number of requests in flight at once; 8 when zero
+	Concurrency int
+	// GapTimeout is how long an ID that is null or fails is retried before
+	// it is sent with its error and skipped; one minute when zero. The API
+	// sometimes answers null for an ID below /maxitem for a few seconds.
+	GapTimeout time.Duration
+	// Buffer is the number of items Items holds for a slow reader. The tail
+	// stops fetching while it is full. Buffered items are not covered by
+	// Checkpoint until the reader receives them.
+	Buffer int
+}
+
+// Tail is a stream of every item created on Hacker News, in ID order
+type Tail struct {
+	items chan ItemResult
+	err   error
+
+	mu   sync.Mutex
+	sent []int // the IDs of the last items sent, up to Buffer+1 of them
+	base int   // the ID received before the first of sent
+}
+
+// TailNewItems polls /maxitem and sends every item between the last one
+// sent and the new maximum, in ID order, on Items. An ID that is briefly
+// null holds the items after it back until it shows up or GapTimeout
+// passes.
+func (c *Client) TailNewItems(ctx context.Context, opts *TailOptions) *Tail {
+	var o TailOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 5 * time.Second
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 100
+	}
+	if o.GapTimeout <= 0 {
+		o.GapTimeout = time.Minute
+	}
+
+	t := &Tail{items: make(chan ItemResult, o.Buffer), base: o.After}
+	go t.run(ctx, c, o)
+	return t
+}
+
+// Items returns the stream of new items. Items that could not be fetched
+// before GapTimeout are sent with their error. It is closed when the tail ends.
+func (t *Tail) Items() <-chan ItemResult {
+	return t.items
+}
+
+// Checkpoint returns the ID of the last item the reader received from
+// Items; items still in the buffer are not covered. Pass it as
+// TailOptions.After to resume from there. An item received but not yet
+// handled when the process dies is lost, so readers that can't afford
+// that should save the ID of the last item they handled instead.
+func (t *Tail) Checkpoint() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// An item sent but not yet recorded counts as buffered, which only
+	// holds the checkpoint back
+	received := len(t.sent) - len(t.items)
+	if received <= 0 {
+		return t.base
+	}
+	return t.sent[received-1]
+}
+
+// Records an item sent on Items
+func (t *Tail) record(id int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sent = append(t.sent, id)
+	if len(t.sent) > cap(t.items)+1 {
+		// The buffer can't hold the oldest one any more: it was received
+		t.base = t.sent[0]
+		t.sent = append(t.sent[:0], t.sent[1:]...)
+	}
+}
+
+// Err returns why the tail ended, once Items is closed
+func (t *Tail) Err() error {
+	return t.err
+}
+
+func (t *Tail) run(ctx context.Context, c *Client, o TailOptions) {
+	defer close(t.items)
+
+	next := o.After + 1
+	var gapSince time.Time
+
+	for {
+		max, err := c.getMaxItemID(ctx)
+		switch {
+		case ctx.Err() != nil:
+			t.err = ctx.Err()
+			return
+		case err != nil && !IsRetryable(err):
+			t.err = err
+			return
+		case err == nil && o.After == 0 && t.Checkpoint() == 0:
+			// Start with the items created from now on
+			o.After = max
+			next = max + 1
+			t.mu.Lock()
+			t.base = max
+			t.mu.Unlock()
+		}
+
+	fetch:
+		for err == nil && next <= max {
+			ids := make([]int, 0, o.BatchSize)
+			for id := next; id <= max && len(ids) < o.BatchSize; id++ {
+				ids = append(ids, id)
+			}
+
+			results, err := c.GetItems(ctx, ids, &BatchOptions{Concurrency: o.Concurrency})
+			if err != nil {
+				t.err = err
+				return
+			}
+
+			for _, r := range results {
+				if r.Err != nil && (errors.Is(r.Err, ErrNotFound) || IsRetryable(r.Err)) {
+					if gapSince.IsZero() {
+						gapSince = time.Now()
+					}
+					if time.Since(gapSince) < o.GapTimeout {
+						break fetch
+					}
+				}
+
+				select {
+				case <-ctx.Done():
+					t.err = ctx.Err()
+					return
+				case t.items <- r:
+				}
+
+				t.record(r.ID)
+				next = r.ID + 1
+				gapSince = time.Time{}
+			}
+		}
+
+		if err := sleep(ctx, o.Interval); err != nil {
+			t.err = err
+			return
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A fake API whose newest items tests create one by one
+type tailStub struct {
+	mu      sync.Mutex
+	max     int
+	missing map[int]bool
+}
+
+func (s *tailStub) set(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+}
+
+func (s *tailStub) register() {
+	mux.HandleFunc("/v0/maxitem.json", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		fmt.Fprint(w, s.max)
+	})
+	mux.HandleFunc("/v0/item/", func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		fmt.Sscanf(r.URL.Path, "/v0/item/%d.json", &id)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if id > s.max || s.missing[id] {
+			fmt.Fprint(w, "null")
+			return
+		}
+		fmt.Fprintf(w, `{"id":%d,"type":"comment"}`, id)
+	})
+}
+
+// Receives the next item, failing the test if none comes within a second
+func nextTailItem(t *testing.T, tail *Tail) ItemResult {
+	select {
+	case r := <-tail.Items():
+		return r
+	case <-time.After(time.Second):
+		t.Fatalf("No item within a second")
+		return ItemResult{}
+	}
+}
+
+func TestTailNewItems(t *testing.T) {
+	setup()
+	defer teardown()
+
+	stub := &tailStub{max: 100, missing: map[int]bool{103: true}}
+	stub.register()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tail := client.TailNewItems(ctx, &TailOptions{After: 98, Interval: 5 * time.Millisecond, BatchSize: 2})
+
+	for _, id := range []int{99, 100} {
+		if r := nextTailItem(t, tail); r.ID != id || r.Err != nil {
+			t.Errorf("Got %+v, was expecting item %d", r, id)
+		}
+	}
+
+	// 103 is late, so 104 and 105 wait for it
+	stub.set(func() { stub.max = 105 })
+
+	for _, id := range []int{101, 102} {
+		if r := nextTailItem(t, tail); r.ID != id || r.Err != nil {
+			t.Errorf("Got %+v, was expecting item %d", r, id)
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if tail.Checkpoint() != 102 {
+		t.Errorf("tail.Checkpoint() = %d while waiting for 103, was expecting 102", tail.Checkpoint())
+	}
+
+	stub.set(func() { delete(stub.missing, 103) })
+
+	for _, id := range []int{103, 104, 105} {
+		if r := nextTailItem(t, tail); r.ID != id || r.Err != nil || r.Item.ID() != id {
+			t.Errorf("Got %+v, was expecting item %d", r, id)
+		}
+	}
+
+	cancel()
+	for range tail.Items() {
+	}
+	if tail.Err() != context.Canceled {
+		t.Errorf("tail.Err() = %v, was expecting %v", tail.Err(), context.Canceled)
+	}
+	if tail.Checkpoint() != 105 {
+		t.Errorf("tail.Checkpoint() = %d, was expecting 105", tail.Checkpoint())
+	}
+}
+
+func TestTailNewItemsGapTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	stub := &tailStub{max: 10, missing: map[int]bool{12: true}}
+	stub.register()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Without After, the tail starts with items created from now on
+	tail := client.TailNewItems(ctx, &TailOptions{Interval: 5 * time.Millisecond, GapTimeout: 30 * time.Millisecond})
+
+	time.Sleep(20 * time.Millisecond)
+	stub.set(func() { stub.max = 13 })
+
+	if r := nextTailItem(t, tail); r.ID != 11 || r.Err != nil {
+		t.Errorf("Got %+v, was expecting item 11", r)
+	}
+
+	// Makes sure 12 is given up on, with its error, after GapTimeout
+	if r := nextTailItem(t, tail); r.ID != 12 || !errors.Is(r.Err, ErrNotFound) {
+		t.Errorf("Got %+v, was expecting item 12 with %v", r, ErrNotFound)
+	}
+
+	if r := nextTailItem(t, tail); r.ID != 13 || r.Err != nil {
+		t.Errorf("Got %+v, was expecting item 13", r)
+	}
+}
+
+func TestTailNewItemsBufferedCheckpoint(t *testing.T) {
+	setup()
+	defer teardown()
+
+	stub := &tailStub{max: 105}
+	stub.register()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tail := client.TailNewItems(ctx, &TailOptions{After: 100, Interval: 5 * time.Millisecond, Buffer: 3})
+
+	// Makes sure items waiting in the buffer don't move the checkpoint
+	time.Sleep(50 * time.Millisecond)
+	if tail.Checkpoint() != 100 {
+		t.Errorf("tail.Checkpoint() = %d before any item was received, was expecting 100", tail.Checkpoint())
+	}
+
+	for id := 101; id <= 104; id++ {
+		if r := nextTailItem(t, tail); r.ID != id {
+			t.Errorf("Got %+v, was expecting item %d", r, id)
+		}
+		time.Sleep(20 * time.Millisecond)
+		if tail.Checkpoint() != id {
+			t.Errorf("tail.Checkpoint() = %d after receiving %d, was expecting %d", tail.Checkpoint(), id, id)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package gophernews
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -34,3 +36,25 @@ func testMethod(t *testing.T, r *http.Request, want string) {
 		t.Errorf("Request method = %v, want %v", r.Method, want)
 	}
 }
+
+// Serves the example JSON in json/<name>.json at the API path of an item
+func handleItemFixture(t *testing.T, name string) {
+	body, err := ioutil.ReadFile("json/" + name + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux.HandleFunc("/v0/item/"+name+".json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	})
+}
+
+// Serves each body in items at the API path of the item with its ID
+func handleItems(items map[int]string) {
+	for id, body := range items {
+		body := body
+		mux.HandleFunc(fmt.Sprintf("/v0/item/%d.json", id), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+	}
+}
@@ -0,0 +1,474 @@
+// Package text renders the HTML Hacker News uses in Comment.Text,
+// Poll.Text, Part.Text and User.About to plain text, to Markdown and to
+// sanitized HTML.
+//
+// That HTML is a small subset: paragraphs start with <p> and are never
+// closed, <i> is italics, <a href> is a link and <pre><code> is a code
+// block. Characters such as ' and / are sent as entities. A paragraph
+// starting with > is a quote.
+package text
+
+import (
+	"html"
+	"strings"
+)
+
+// Returns the text of s without markup. Paragraphs are separated by a blank
+// line, links show their URL and code blocks keep their indentation.
+func PlainText(s string) string {
+	var b strings.Builder
+	for n, blk := range parse(s) {
+		if n > 0 {
+			b.WriteString("\n\n")
+		}
+		if blk.code {
+			b.WriteString(strings.TrimRight(blk.raw, "\n"))
+			continue
+		}
+		plainInlines(&b, blk.inlines)
+	}
+	return b.String()
+}
+
+func plainInlines(b *strings.Builder, nodes []*node) {
+	for _, nd := range nodes {
+		switch nd.tag {
+		case "":
+			b.WriteString(nd.text)
+		case "i":
+			plainInlines(b, nd.kids)
+		case "a":
+			label := textOf(nd.kids)
+			switch {
+			case nd.href == "" || label == nd.href:
+				b.WriteString(label)
+			case isShortened(label, nd.href):
+				b.WriteString(nd.href)
+			default:
+				b.WriteString(label + " (" + nd.href + ")")
+			}
+		}
+	}
+}
+
+// Returns s as Markdown. Quotes become block quotes, code blocks are fenced
+// and Markdown's special characters in the text are escaped.
+func Markdown(s string) string {
+	var b strings.Builder
+	for n, blk := range parse(s) {
+		if n > 0 {
+			b.WriteString("\n\n")
+		}
+		switch {
+		case blk.code:
+			code := dedent(strings.TrimRight(blk.raw, "\n"))
+			fence := strings.Repeat("`", max(3, longestRun(code, '`')+1))
+			b.WriteString(fence + "\n" + code + "\n" + fence)
+		case blk.quote():
+			b.WriteString("> ")
+			var inner strings.Builder
+			markdownInlines(&inner, unquote(blk.inlines))
+			b.WriteString(escapeLineStart(inner.String()))
+		default:
+			var inner strings.Builder
+			markdownInlines(&inner, blk.inlines)
+			b.WriteString(escapeLineStart(inner.String()))
+		}
+	}
+	return b.String()
+}
+
+func markdownInlines(b *strings.Builder, nodes []*node) {
+	for _, nd := range nodes {
+		switch nd.tag {
+		case "":
+			b.WriteString(markdownEscaper.Replace(nd.text))
+		case "i":
+			var inner strings.Builder
+			markdownInlines(&inner, nd.kids)
+			if strings.TrimSpace(inner.String()) == "" {
+				b.WriteString(inner.String())
+			} else {
+				b.WriteString("*" + inner.String() + "*")
+			}
+		case "a":
+			label := textOf(nd.kids)
+			switch {
+			case nd.href == "" || !safeURL(nd.href):
+				markdownInlines(b, nd.kids)
+			case label == nd.href || isShortened(label, nd.href):
+				b.WriteString("<" + markdownURL(nd.href) + ">")
+			default:
+				b.WriteString("[")
+				markdownInlines(b, nd.kids)
+				b.WriteString("](" + markdownURL(nd.href) + ")")
+			}
+		}
+	}
+}
+
+// Characters that are markup anywhere in a line of Markdown
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+)
+
+// Escapes what Markdown would read as a heading, list or quote at the start
+// of a paragraph
+func escapeLineStart(s string) string {
+	switch {
+	case strings.HasPrefix(s, "#"), strings.HasPrefix(s, ">"),
+		strings.HasPrefix(s, "- "), strings.HasPrefix(s, "+ "):
+		return `\` + s
+	}
+
+	// Ordered lists: 1. or 1)
+	digits := 0
+	for digits < len(s) && s[digits] >= '0' && s[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits < len(s) && (s[digits] == '.' || s[digits] == ')') {
+		return s[:digits] + `\` + s[digits:]
+	}
+
+	return s
+}
+
+// Percent-encodes the characters that would end a Markdown link early
+func markdownURL(u string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E").Replace(u)
+}
+
+// Returns s as HTML that is safe to embed in a page. Only paragraphs,
+// italics, links to http, https and mailto URLs, code blocks and quotes
+// are kept, and every attribute but href is dropped.
+func SanitizeHTML(s string) string {
+	var b strings.Builder
+	for _, blk := range parse(s) {
+		switch {
+		case blk.code:
+			b.WriteString("<pre><code>" + html.EscapeString(strings.TrimRight(blk.raw, "\n")) + "</code></pre>")
+		case blk.quote():
+			b.WriteString("<blockquote><p>")
+			htmlInlines(&b, unquote(blk.inlines))
+			b.WriteString("</p></blockquote>")
+		default:
+			b.WriteString("<p>")
+			htmlInlines(&b, blk.inlines)
+			b.WriteString("</p>")
+		}
+	}
+	return b.String()
+}
+
+func htmlInlines(b *strings.Builder, nodes []*node) {
+	for _, nd := range nodes {
+		switch nd.tag {
+		case "":
+			b.WriteString(html.EscapeString(nd.text))
+		case "i":
+			b.WriteString("<i>")
+			htmlInlines(b, nd.kids)
+			b.WriteString("</i>")
+		case "a":
+			if !safeURL(nd.href) {
+				htmlInlines(b, nd.kids)
+				continue
+			}
+			b.WriteString(`<a href="` + html.EscapeString(nd.href) + `" rel="nofollow">`)
+			htmlInlines(b, nd.kids)
+			b.WriteString("</a>")
+		}
+	}
+}
+
+// Reports whether a link may be kept: javascript: and other schemes are not
+func safeURL(u string) bool {
+	lower := strings.ToLower(strings.TrimSpace(u))
+	for _, scheme := range []string{"http://", "https://", "mailto:"} {
+		if strings.HasPrefix(lower, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether label is the start of href cut short with "...", as
+// Hacker News shows long URLs
+func isShortened(label, href string) bool {
+	prefix := strings.TrimSuffix(label, "...")
+	return prefix != label && prefix != "" && strings.HasPrefix(href, prefix)
+}
+
+// Removes the indentation every non-blank line of a code block shares
+func dedent(code string) string {
+	lines := strings.Split(code, "\n")
+
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent <= 0 {
+		return code
+	}
+
+	for n, line := range lines {
+		if len(line) >= indent {
+			lines[n] = line[indent:]
+		} else {
+			lines[n] = strings.TrimLeft(line, " \t")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the length of the longest run of c in s
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for n := 0; n < len(s); n++ {
+		if s[n] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// A paragraph or a code block
+type block struct {
+	code    bool
+	raw     string  // the text of a code block
+	inlines []*node // the content of a paragraph
+}
+
+// Reports whether a paragraph is a quote, i.e. starts with >
+func (blk block) quote() bool {
+	return !blk.code && len(blk.inlines) > 0 && blk.inlines[0].tag == "" &&
+		strings.HasPrefix(strings.TrimLeft(blk.inlines[0].text, " "), ">")
+}
+
+// Returns the inlines of a quote without its leading >
+func unquote(nodes []*node) []*node {
+	first := *nodes[0]
+	first.text = strings.TrimLeft(strings.TrimPrefix(strings.TrimLeft(first.text, " "), ">"), " ")
+	return append([]*node{&first}, nodes[1:]...)
+}
+
+// Text, or an <i> or <a> element, inside a paragraph
+type node struct {
+	tag  string // "" for text
+	text string // unescaped
+	href string
+	kids []*node
+}
+
+// Returns the text of nodes and all their descendants
+func textOf(nodes []*node) string {
+	var b strings.Builder
+	for _, nd := range nodes {
+		b.WriteString(nd.text)
+		b.WriteString(textOf(nd.kids))
+	}
+	return b.String()
+}
+
+// Parses Hacker News HTML into blocks. It never fails: unknown tags are
+// dropped with their text kept, and unclosed elements end with their block.
+func parse(s string) []block {
+	var p parser
+	for len(s) > 0 {
+		lt := strings.IndexByte(s, '<')
+		if lt < 0 {
+			p.text(s)
+			break
+		}
+		if lt > 0 {
+			p.text(s[:lt])
+			s = s[lt:]
+		}
+
+		gt := strings.IndexByte(s, '>')
+		if gt < 0 {
+			p.text(s)
+			break
+		}
+		name, closing, href := parseTag(s[1:gt])
+		if name == "" {
+			// Not a tag, such as a lone "< 3"
+			p.text(s[:1])
+			s = s[1:]
+			continue
+		}
+		p.tag(name, closing, href)
+		s = s[gt+1:]
+	}
+	p.endBlock()
+	return p.blocks
+}
+
+type parser struct {
+	blocks []block
+	cur    block
+	open   []*node // the <i> and <a> elements text goes into, innermost last
+	inPre  bool
+	pre    strings.Builder
+}
+
+func (p *parser) text(raw string) {
+	t := html.UnescapeString(raw)
+	if p.inPre {
+		p.pre.WriteString(t)
+		return
+	}
+	if t == "" {
+		return
+	}
+
+	nd := &node{text: t}
+	if len(p.open) > 0 {
+		parent := p.open[len(p.open)-1]
+		parent.kids = append(parent.kids, nd)
+	} else {
+		p.cur.inlines = append(p.cur.inlines, nd)
+	}
+}
+
+func (p *parser) tag(name string, closing bool, href string) {
+	if p.inPre {
+		if name == "pre" && closing {
+			p.blocks = append(p.blocks, block{code: true, raw: p.pre.String()})
+			p.pre.Reset()
+			p.inPre = false
+		}
+		// <code> and anything else inside a code block is dropped
+		return
+	}
+
+	switch {
+	case name == "p" || name == "br":
+		p.endBlock()
+	case name == "pre" && !closing:
+		p.endBlock()
+		p.inPre = true
+	case (name == "i" || name == "a") && !closing:
+		nd := &node{tag: name, href: href}
+		if len(p.open) > 0 {
+			parent := p.open[len(p.open)-1]
+			parent.kids = append(parent.kids, nd)
+		} else {
+			p.cur.inlines = append(p.cur.inlines, nd)
+		}
+		p.open = append(p.open, nd)
+	case name == "i" || name == "a":
+		// Close up to the innermost matching element
+		for n := len(p.open) - 1; n >= 0; n-- {
+			if p.open[n].tag == name {
+				p.open = p.open[:n]
+				break
+			}
+		}
+	}
+}
+
+// Ends the current paragraph, or code block if its </pre> is missing
+func (p *parser) endBlock() {
+	if p.inPre {
+		p.blocks = append(p.blocks, block{code: true, raw: p.pre.String()})
+		p.pre.Reset()
+		p.inPre = false
+	}
+	if strings.TrimSpace(textOf(p.cur.inlines)) != "" {
+		trimSpace(p.cur.inlines)
+		p.blocks = append(p.blocks, p.cur)
+	}
+	p.cur = block{}
+	p.open = nil
+}
+
+// Removes the white space around a paragraph, such as the line break
+// after a </pre>
+func trimSpace(nodes []*node) {
+	if first := nodes[0]; first.tag == "" {
+		first.text = strings.TrimLeft(first.text, " \t\n\r")
+	}
+	if last := nodes[len(nodes)-1]; last.tag == "" {
+		last.text = strings.TrimRight(last.text, " \t\n\r")
+	}
+}
+
+// Parses the inside of a tag such as `a href="x" rel="nofollow"` or `/i`.
+// name is "" when s isn't a tag.
+func parseTag(s string) (name string, closing bool, href string) {
+	if strings.HasPrefix(s, "/") {
+		closing = true
+		s = s[1:]
+	}
+	s = strings.TrimSuffix(s, "/")
+
+	end := 0
+	for end < len(s) && isLetter(s[end]) {
+		end++
+	}
+	if end == 0 || end < len(s) && !isSpace(s[end]) {
+		return "", false, ""
+	}
+	name = strings.ToLower(s[:end])
+
+	attrs := s[end:]
+	for {
+		attrs = strings.TrimLeft(attrs, " \t\n\r")
+		if attrs == "" {
+			break
+		}
+
+		eq := strings.IndexByte(attrs, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(attrs[:eq]))
+		attrs = strings.TrimLeft(attrs[eq+1:], " \t\n\r")
+
+		var value string
+		if attrs != "" && (attrs[0] == '"' || attrs[0] == '\'') {
+			closeQuote := strings.IndexByte(attrs[1:], attrs[0])
+			if closeQuote < 0 {
+				value, attrs = attrs[1:], ""
+			} else {
+				value, attrs = attrs[1:closeQuote+1], attrs[closeQuote+2:]
+			}
+		} else {
+			sp := strings.IndexAny(attrs, " \t\n\r")
+			if sp < 0 {
+				sp = len(attrs)
+			}
+			value, attrs = attrs[:sp], attrs[sp:]
+		}
+
+		if key == "href" {
+			href = html.UnescapeString(value)
+		}
+	}
+
+	return name, closing, href
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
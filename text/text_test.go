@@ -0,0 +1,72 @@
+package text
+
+import "testing"
+
+// The about text of json/chimeracoder.json
+const about = `Inveterate builder. Cofounder of BoardRounds (https:&#x2F;&#x2F;www.boardrounds.com&#x2F;)<p>Personal site: http:&#x2F;&#x2F;www.adityamukerjee.net&#x2F;<p>My email is pretty easy to guess from my username.`
+
+// The text of json/192327.json
+const job = `Justin.tv is the biggest live video site online.<p>Completing the technical problem at <a href="http:&#x2F;&#x2F;www.justin.tv&#x2F;problems&#x2F;bml" rel="nofollow">http:&#x2F;&#x2F;www.justin.tv&#x2F;problems&#x2F;bml</a> will go a long way with us. Cheers!`
+
+const comment = `&gt; I don&#x27;t think <i>anyone</i> needs this.<p>See <a href="https:&#x2F;&#x2F;example.com&#x2F;a_very&#x2F;long&#x2F;path" rel="nofollow">https:&#x2F;&#x2F;example.com&#x2F;a_very&#x2F;lo...</a> and <a href="https:&#x2F;&#x2F;go.dev&#x2F;">the docs</a>:<p><pre><code>  if x &lt; 3 {
+      return *p
+  }
+</code></pre>
+That&#x27;s 2 * 3 = 6.`
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"about", about, "Inveterate builder. Cofounder of BoardRounds (https://www.boardrounds.com/)\n\nPersonal site: http://www.adityamukerjee.net/\n\nMy email is pretty easy to guess from my username."},
+		{"job", job, "Justin.tv is the biggest live video site online.\n\nCompleting the technical problem at http://www.justin.tv/problems/bml will go a long way with us. Cheers!"},
+		{"comment", comment, "> I don't think anyone needs this.\n\nSee https://example.com/a_very/long/path and the docs (https://go.dev/):\n\n  if x < 3 {\n      return *p\n  }\n\nThat's 2 * 3 = 6."},
+		{"empty", "", ""},
+		{"unclosed", "<i>never closed<p>next", "never closed\n\nnext"},
+		{"lone bracket", "a < b and c > d", "a < b and c > d"},
+	}
+
+	for _, tt := range tests {
+		if got := PlainText(tt.in); got != tt.want {
+			t.Errorf("%s: PlainText returned\n%q\nwant\n%q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"job", job, "Justin.tv is the biggest live video site online.\n\nCompleting the technical problem at <http://www.justin.tv/problems/bml> will go a long way with us. Cheers!"},
+		{"comment", comment, "> I don't think *anyone* needs this.\n\nSee <https://example.com/a_very/long/path> and [the docs](https://go.dev/):\n\n```\nif x < 3 {\n    return *p\n}\n```\n\nThat's 2 \\* 3 = 6."},
+		{"escapes", "# not a heading<p>1. not a list<p>snake_case [x]", "\\# not a heading\n\n1\\. not a list\n\nsnake\\_case \\[x\\]"},
+		{"fence", "<pre><code>```go\n```</code></pre>", "````\n```go\n```\n````"},
+		{"unsafe link", `<a href="javascript:alert(1)">click</a>`, "click"},
+	}
+
+	for _, tt := range tests {
+		if got := Markdown(tt.in); got != tt.want {
+			t.Errorf("%s: Markdown returned\n%q\nwant\n%q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"comment", comment, `<blockquote><p>I don&#39;t think <i>anyone</i> needs this.</p></blockquote>` +
+			`<p>See <a href="https://example.com/a_very/long/path" rel="nofollow">https://example.com/a_very/lo...</a> and <a href="https://go.dev/" rel="nofollow">the docs</a>:</p>` +
+			"<pre><code>  if x &lt; 3 {\n      return *p\n  }</code></pre>" +
+			`<p>That&#39;s 2 * 3 = 6.</p>`},
+		{"script", `hi<script>alert("x")</script>`, `<p>hialert(&#34;x&#34;)</p>`},
+		{"attributes", `<i onclick="x()">a</i> <a href="http://a.b/" onmouseover="x()">b</a>`, `<p><i>a</i> <a href="http://a.b/" rel="nofollow">b</a></p>`},
+		{"unsafe link", `<a href="javascript:alert(1)">click</a>`, `<p>click</p>`},
+	}
+
+	for _, tt := range tests {
+		if got := SanitizeHTML(tt.in); got != tt.want {
+			t.Errorf("%s: SanitizeHTML returned\n%q\nwant\n%q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package gophernews
+
+import (
+	"context"
+	"errors"
+)
+
+// ThreadOptions tunes GetThread. A nil *ThreadOptions loads the whole tree.
+type ThreadOptions struct {
+	// MaxDepth stops the walk below this depth; top-level comments are at
+	// depth 1. Zero means no limit.
+	MaxDepth int
+	// MaxNodes stops the walk once this many comments are in the tree.
+	// Missing and skipped comments don't count. Zero means no limit.
+	MaxNodes int
+	// Concurrency is the number of requests in flight at once; 8 when zero
+	Concurrency int
+	// SkipDeleted leaves deleted comments, and the replies below them, out of the tree
+	SkipDeleted bool
+	// SkipDead leaves dead comments, and the replies below them, out of the tree
+	SkipDead bool
+}
+
+// Thread is the comment tree below a story, poll or comment
+type Thread struct {
+	Root Item
+	// Comments are the direct replies to Root, in display order
+	Comments []*CommentNode
+	// Count is the number of comments in the tree
+	Count int
+	// Truncated is set when MaxDepth or MaxNodes cut the walk short
+	Truncated bool
+}
+
+// CommentNode is a comment within a Thread
+type CommentNode struct {
+	Comment Comment
+	// Depth is 1 for direct replies to the thread's root
+	Depth int
+	// Parent is nil for direct replies to the thread's root
+	Parent *CommentNode
+	// Replies are the direct replies to Comment, in display order
+	Replies []*CommentNode
+}
+
+// Walk visits every comment of the thread depth first, in display order,
+// until fn returns false
+func (t *Thread) Walk(fn func(*CommentNode) bool) {
+	walkNodes(t.Comments, fn)
+}
+
+func walkNodes(nodes []*CommentNode, fn func(*CommentNode) bool) bool {
+	for _, n := range nodes {
+		if !fn(n) || !walkNodes(n.Replies, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetThread loads the comment tree below rootID, one level at a time, by
+// following Kids recursively. Comments that no longer exist are left out;
+// any other failure to load a comment fails the whole thread.
+func (c *Client) GetThread(ctx context.Context, rootID int, opts *ThreadOptions) (*Thread, error) {
+	if opts == nil {
+		opts = &ThreadOptions{}
+	}
+
+	root, err := c.GetItemContext(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	thread := &Thread{Root: root}
+
+	// The IDs to load at the current depth, and the node each one replies to
+	ids := root.Kids()
+	parents := make([]*CommentNode, len(ids))
+
+	for depth := 1; len(ids) > 0; depth++ {
+		if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+			thread.Truncated = true
+			break
+		}
+
+		var nextIDs []int
+		var nextParents []*CommentNode
+
+		// Loads the level in batches no larger than the room left under
+		// MaxNodes, so IDs that turn out missing or skipped are made up for
+		for len(ids) > 0 {
+			batch := ids
+			if opts.MaxNodes > 0 {
+				room := opts.MaxNodes - thread.Count
+				if room <= 0 {
+					break
+				}
+				if len(batch) > room {
+					batch = batch[:room]
+				}
+			}
+
+			results, err := c.GetItems(ctx, batch, &BatchOptions{Concurrency: opts.Concurrency})
+			if err != nil {
+				return nil, err
+			}
+
+			for n, r := range results {
+				if errors.Is(r.Err, ErrNotFound) {
+					continue
+				}
+				if r.Err != nil {
+					return nil, r.Err
+				}
+
+				comment, err := r.Item.(item).ToComment()
+				if err != nil || opts.SkipDeleted && comment.Deleted || opts.SkipDead && comment.Dead {
+					continue
+				}
+
+				node := &CommentNode{Comment: comment, Depth: depth, Parent: parents[n]}
+				if node.Parent == nil {
+					thread.Comments = append(thread.Comments, node)
+				} else {
+					node.Parent.Replies = append(node.Parent.Replies, node)
+				}
+				thread.Count++
+
+				for _, kid := range node.Comment.Kids {
+					nextIDs = append(nextIDs, kid)
+					nextParents = append(nextParents, node)
+				}
+			}
+
+			ids, parents = ids[len(batch):], parents[len(batch):]
+		}
+
+		if len(ids) > 0 {
+			thread.Truncated = true
+			break
+		}
+
+		ids, parents = nextIDs, nextParents
+	}
+
+	return thread, nil
+}
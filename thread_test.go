@@ -0,0 +1,125 @@
+package gophernews
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// Sets up API stubs for a story with this comment tree:
+//
+//	8863
+//	├── 101
+//	│   ├── 103
+//	│   │   └── 105
+//	│   └── 104 (deleted)
+//	│       └── 106
+//	└── 102 (dead)
+func setupThread() {
+	items := map[int]string{
+		8863: `{"by":"dhouston","descendants":6,"id":8863,"kids":[101,102],"title":"My YC app: Dropbox","type":"story"}`,
+		101:  `{"by":"a","id":101,"kids":[103,104],"parent":8863,"text":"first","type":"comment"}`,
+		102:  `{"by":"b","dead":true,"id":102,"parent":8863,"text":"[flagged]","type":"comment"}`,
+		103:  `{"by":"c","id":103,"kids":[105],"parent":101,"text":"reply","type":"comment"}`,
+		104:  `{"deleted":true,"id":104,"kids":[106],"parent":101,"type":"comment"}`,
+		105:  `{"by":"d","id":105,"parent":103,"text":"deep reply","type":"comment"}`,
+		106:  `{"by":"e","id":106,"parent":104,"text":"orphan","type":"comment"}`,
+	}
+	handleItems(items)
+}
+
+// Flattens a thread into "depth:id" strings, in the order Walk visits them
+func flattenThread(t *Thread) []string {
+	var flat []string
+	t.Walk(func(n *CommentNode) bool {
+		flat = append(flat, fmt.Sprintf("%d:%d", n.Depth, n.Comment.ID))
+		return true
+	})
+	return flat
+}
+
+func TestGetThread(t *testing.T) {
+	setup()
+	defer teardown()
+	setupThread()
+
+	thread, err := client.GetThread(context.Background(), 8863, nil)
+	if err != nil {
+		t.Fatalf("Error for client.GetThread(ctx, 8863, nil) should have been nil. Was: %v", err)
+	}
+
+	// Makes sure the whole tree was loaded in display order
+	expected := "[1:101 2:103 3:105 2:104 3:106 1:102]"
+	if got := fmt.Sprint(flattenThread(thread)); got != expected {
+		t.Errorf("client.GetThread(ctx, 8863, nil) returned %s, was expecting %s", got, expected)
+	}
+	if thread.Count != 6 || thread.Truncated || thread.Root.ID() != 8863 {
+		t.Errorf("client.GetThread(ctx, 8863, nil) returned %+v, was expecting 6 comments below 8863", thread)
+	}
+
+	// Checks the parent pointers
+	deep := thread.Comments[0].Replies[0].Replies[0]
+	if deep.Comment.ID != 105 || deep.Parent.Comment.ID != 103 || deep.Parent.Parent.Comment.ID != 101 || deep.Parent.Parent.Parent != nil {
+		t.Errorf("Comment 105 has the wrong ancestors: %+v", deep)
+	}
+}
+
+func TestGetThreadOptions(t *testing.T) {
+	setup()
+	defer teardown()
+	setupThread()
+
+	cases := []struct {
+		opts      ThreadOptions
+		expected  string
+		truncated bool
+	}{
+		{ThreadOptions{SkipDeleted: true, SkipDead: true}, "[1:101 2:103 3:105]", false},
+		{ThreadOptions{MaxDepth: 2}, "[1:101 2:103 2:104 1:102]", true},
+		{ThreadOptions{MaxNodes: 3}, "[1:101 2:103 1:102]", true},
+		{ThreadOptions{MaxDepth: 3, Concurrency: 1}, "[1:101 2:103 3:105 2:104 3:106 1:102]", false},
+	}
+
+	for _, c := range cases {
+		thread, err := client.GetThread(context.Background(), 8863, &c.opts)
+		if err != nil {
+			t.Errorf("Error for client.GetThread with %+v should have been nil. Was: %v", c.opts, err)
+			continue
+		}
+		if got := fmt.Sprint(flattenThread(thread)); got != c.expected || thread.Truncated != c.truncated {
+			t.Errorf("client.GetThread with %+v returned %s (truncated: %v), was expecting %s (truncated: %v)",
+				c.opts, got, thread.Truncated, c.expected, c.truncated)
+		}
+	}
+}
+
+func TestGetThreadMaxNodesSkipped(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stubs for a story whose first replies are missing or deleted
+	items := map[int]string{
+		1: `{"id":1,"kids":[2,3,4,5],"type":"story"}`,
+		2: `null`,
+		3: `{"deleted":true,"id":3,"parent":1,"type":"comment"}`,
+		4: `{"by":"a","id":4,"parent":1,"type":"comment"}`,
+		5: `{"by":"b","id":5,"parent":1,"type":"comment"}`,
+	}
+	handleItems(items)
+
+	thread, err := client.GetThread(context.Background(), 1, &ThreadOptions{MaxNodes: 2, SkipDeleted: true})
+	if err != nil {
+		t.Fatalf("Error for client.GetThread should have been nil. Was: %v", err)
+	}
+
+	// Makes sure the skipped replies are made up for by the next ones
+	if got := fmt.Sprint(flattenThread(thread)); got != "[1:4 1:5]" || thread.Truncated {
+		t.Errorf("client.GetThread returned %s (truncated: %v), was expecting [1:4 1:5] (truncated: false)", got, thread.Truncated)
+	}
+
+	thread, err = client.GetThread(context.Background(), 1, &ThreadOptions{MaxNodes: 1, SkipDeleted: true})
+	if err != nil || fmt.Sprint(flattenThread(thread)) != "[1:4]" || !thread.Truncated {
+		t.Errorf("client.GetThread with MaxNodes 1 returned %v (truncated: %v), %v, was expecting [1:4] (truncated: true)",
+			flattenThread(thread), thread.Truncated, err)
+	}
+}
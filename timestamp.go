@@ -0,0 +1,145 @@
+package gophernews
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a point in time the API sends as Unix seconds, such as the
+// time of an item or the creation of a user. The zero Timestamp stands for
+// a missing time and is sent as 0. Times are in UTC.
+//
+// Timestamp encodes to Unix seconds in every format, overriding the RFC 3339
+// text and binary forms of the embedded time.Time.
+type Timestamp struct {
+	time.Time
+}
+
+// Returns the Timestamp of a Unix time in seconds. 0 gives the zero Timestamp.
+func UnixTimestamp(sec int64) Timestamp {
+	if sec == 0 {
+		return Timestamp{}
+	}
+	return Timestamp{time.Unix(sec, 0).UTC()}
+}
+
+// Decodes Unix seconds. null and 0 decode to the zero Timestamp.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if isNull(data) {
+		*t = Timestamp{}
+		return nil
+	}
+
+	var sec int64
+	if err := json.Unmarshal(data, &sec); err != nil {
+		return fmt.Errorf("gophernews: timestamp %s: %w", bytes.TrimSpace(data), err)
+	}
+
+	*t = UnixTimestamp(sec)
+	return nil
+}
+
+// Encodes the Timestamp back to Unix seconds
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+	return strconv.AppendInt(nil, t.Unix(), 10), nil
+}
+
+// Encodes the Timestamp to Unix seconds, as MarshalJSON does
+func (t Timestamp) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Appends the Unix seconds of the Timestamp to b, as MarshalText does
+func (t Timestamp) AppendText(b []byte) ([]byte, error) {
+	text, _ := t.MarshalJSON()
+	return append(b, text...), nil
+}
+
+// Decodes Unix seconds, as UnmarshalJSON does
+func (t *Timestamp) UnmarshalText(data []byte) error {
+	return t.UnmarshalJSON(data)
+}
+
+// Encodes the Timestamp to Unix seconds for encoding/gob
+func (t Timestamp) GobEncode() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Decodes the Unix seconds written by GobEncode
+func (t *Timestamp) GobDecode(data []byte) error {
+	return t.UnmarshalJSON(data)
+}
+
+// Encodes the Timestamp to Unix seconds, as MarshalJSON does
+func (t Timestamp) MarshalBinary() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// Appends the Unix seconds of the Timestamp to b, as MarshalBinary does
+func (t Timestamp) AppendBinary(b []byte) ([]byte, error) {
+	return t.AppendText(b)
+}
+
+// Decodes the Unix seconds written by MarshalBinary
+func (t *Timestamp) UnmarshalBinary(data []byte) error {
+	return t.UnmarshalJSON(data)
+}
+
+// Age returns the time elapsed since t
+func (t Timestamp) Age() time.Duration {
+	return time.Since(t.Time)
+}
+
+// Ago formats the age of t the way Hacker News does, e.g. "3 hours ago"
+func (t Timestamp) Ago() string {
+	return t.RelativeTo(time.Now())
+}
+
+// RelativeTo formats t relative to now, e.g. "3 hours ago", or "in 3 hours"
+// for a time after now. Times less than a minute apart are "just now".
+func (t Timestamp) RelativeTo(now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	d := now.Sub(t.Time)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var n int64
+	var unit string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		n, unit = int64(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int64(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int64(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int64(d/(30*24*time.Hour)), "month"
+		// 360 days and over are 12 months, which is a year
+		if n >= 12 {
+			n, unit = 1, "year"
+		}
+	default:
+		n, unit = int64(d/(365*24*time.Hour)), "year"
+	}
+
+	if n != 1 {
+		unit += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
@@ -0,0 +1,142 @@
+package gophernews
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// Makes sure a Timestamp decodes from and encodes to Unix seconds
+func TestTimestampJSON(t *testing.T) {
+	var s Story
+	if err := json.Unmarshal([]byte(`{"id":8863,"time":1175714200}`), &s); err != nil {
+		t.Fatalf("Error for json.Unmarshal should have been nil. Was: %v", err)
+	}
+	if !s.Time.Equal(time.Unix(1175714200, 0)) {
+		t.Errorf("Time was %v, was expecting %v", s.Time, time.Unix(1175714200, 0))
+	}
+
+	body, err := json.Marshal(s.Time)
+	if err != nil || string(body) != "1175714200" {
+		t.Errorf("json.Marshal returned %s, %v, was expecting 1175714200", body, err)
+	}
+
+	for _, missing := range []string{`{}`, `{"time":null}`, `{"time":0}`} {
+		var s Story
+		if err := json.Unmarshal([]byte(missing), &s); err != nil || !s.Time.IsZero() {
+			t.Errorf("json.Unmarshal of %s gave %v, %v, was expecting the zero Timestamp", missing, s.Time, err)
+		}
+	}
+
+	if body, _ := json.Marshal(Timestamp{}); string(body) != "0" {
+		t.Errorf("json.Marshal of the zero Timestamp returned %s, was expecting 0", body)
+	}
+
+	if err := json.Unmarshal([]byte(`{"time":"yesterday"}`), &s); err == nil {
+		t.Errorf("Error for json.Unmarshal of a string time should not have been nil")
+	}
+}
+
+// Makes sure an item's time carries over to the typed item
+func TestTimestampItem(t *testing.T) {
+	var i item
+	if err := json.Unmarshal([]byte(`{"id":1,"time":1314211127,"type":"comment"}`), &i); err != nil {
+		t.Fatalf("Error for json.Unmarshal should have been nil. Was: %v", err)
+	}
+
+	if sec := i.Time().Unix(); sec != 1314211127 {
+		t.Errorf("Time was %d, was expecting 1314211127", sec)
+	}
+
+	c, _ := i.ToComment()
+	if !c.Time.Equal(i.Time().Time) {
+		t.Errorf("ToComment set Time to %v, was expecting %v", c.Time, i.Time())
+	}
+}
+
+// Makes sure RelativeTo formats ages the way Hacker News does
+func TestTimestampRelativeTo(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		ago      time.Duration
+		expected string
+	}{
+		{0, "just now"},
+		{59 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{45 * time.Minute, "45 minutes ago"},
+		{3 * time.Hour, "3 hours ago"},
+		{day, "1 day ago"},
+		{29 * day, "29 days ago"},
+		{60 * day, "2 months ago"},
+		{359 * day, "11 months ago"},
+		{362 * day, "1 year ago"},
+		{800 * day, "2 years ago"},
+		{-2 * time.Hour, "in 2 hours"},
+	}
+
+	for _, tt := range tests {
+		ts := Timestamp{now.Add(-tt.ago)}
+		if relative := ts.RelativeTo(now); relative != tt.expected {
+			t.Errorf("RelativeTo for %v ago returned %q, was expecting %q", tt.ago, relative, tt.expected)
+		}
+	}
+
+	if relative := (Timestamp{}).RelativeTo(now); relative != "" {
+		t.Errorf("RelativeTo for the zero Timestamp returned %q, was expecting \"\"", relative)
+	}
+}
+
+// Makes sure Age and Ago measure from now
+func TestTimestampAge(t *testing.T) {
+	ts := Timestamp{time.Now().Add(-time.Hour)}
+	if age := ts.Age(); age < time.Hour || age > time.Hour+time.Minute {
+		t.Errorf("Age was %v, was expecting about an hour", age)
+	}
+	if ago := ts.Ago(); ago != "1 hour ago" {
+		t.Errorf("Ago returned %q, was expecting \"1 hour ago\"", ago)
+	}
+}
+
+// Makes sure Timestamps are in UTC whatever the local time zone
+func TestTimestampUTC(t *testing.T) {
+	if loc := UnixTimestamp(1175714200).Location(); loc != time.UTC {
+		t.Errorf("UnixTimestamp was in %v, was expecting UTC", loc)
+	}
+	if formatted := UnixTimestamp(1175714200).Format(time.RFC822); formatted != "04 Apr 07 19:16 UTC" {
+		t.Errorf("Format returned %q, was expecting \"04 Apr 07 19:16 UTC\"", formatted)
+	}
+}
+
+// Makes sure the text and gob forms are Unix seconds too
+func TestTimestampEncodings(t *testing.T) {
+	ts := UnixTimestamp(1175714200)
+
+	if text, err := ts.MarshalText(); err != nil || string(text) != "1175714200" {
+		t.Errorf("MarshalText returned %s, %v, was expecting 1175714200", text, err)
+	}
+
+	var fromText Timestamp
+	if err := fromText.UnmarshalText([]byte("1175714200")); err != nil || fromText != ts {
+		t.Errorf("UnmarshalText gave %v, %v, was expecting %v", fromText, err, ts)
+	}
+
+	// Maps keyed by Timestamp use the text form too
+	body, err := json.Marshal(map[Timestamp]int{ts: 1})
+	if err != nil || string(body) != `{"1175714200":1}` {
+		t.Errorf("json.Marshal of a map returned %s, %v, was expecting {\"1175714200\":1}", body, err)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(User{ID: "pg", Created: ts}); err != nil {
+		t.Fatalf("Error for gob Encode should have been nil. Was: %v", err)
+	}
+	var u User
+	if err := gob.NewDecoder(&buf).Decode(&u); err != nil || u.Created != ts {
+		t.Errorf("gob Decode gave %v, %v, was expecting %v", u.Created, err, ts)
+	}
+}
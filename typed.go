@@ -0,0 +1,118 @@
+package gophernews
+
+import (
+	"context"
+	"fmt"
+)
+
+// TypedItem is the concrete value of an item whose type is only known once
+// it has been fetched. It is implemented by *Story, *Comment, *Poll, *Part
+// and *Job, and by nothing outside this package.
+type TypedItem interface {
+	ItemID() int
+	ItemType() string
+	typedItem()
+}
+
+func (s *Story) ItemID() int   { return s.ID }
+func (c *Comment) ItemID() int { return c.ID }
+func (p *Poll) ItemID() int    { return p.ID }
+func (p *Part) ItemID() int    { return p.ID }
+func (j *Job) ItemID() int     { return j.ID }
+
+func (s *Story) ItemType() string   { return s.Type }
+func (c *Comment) ItemType() string { return c.Type }
+func (p *Poll) ItemType() string    { return p.Type }
+func (p *Part) ItemType() string    { return p.Type }
+func (j *Job) ItemType() string     { return j.Type }
+
+func (*Story) typedItem()   {}
+func (*Comment) typedItem() {}
+func (*Poll) typedItem()    {}
+func (*Part) typedItem()    {}
+func (*Job) typedItem()     {}
+
+// Makes an API request and puts response into whichever of Story, Comment,
+// Poll, Part or Job matches the item's type
+func (c *Client) GetAny(id int) (TypedItem, error) {
+	return c.GetAnyContext(context.Background(), id)
+}
+
+// Same as GetAny, but the request is bound to ctx
+func (c *Client) GetAnyContext(ctx context.Context, id int) (TypedItem, error) {
+	item, err := c.GetItemContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkSkipped(id, item); err != nil {
+		return nil, err
+	}
+
+	return item.ToTyped()
+}
+
+// Convert an item to the typed value matching its type
+func (i item) ToTyped() (TypedItem, error) {
+	switch i.Type() {
+	case "story":
+		s, err := i.ToStory()
+		return &s, err
+	case "comment":
+		c, err := i.ToComment()
+		return &c, err
+	case "poll":
+		p, err := i.ToPoll()
+		return &p, err
+	case "pollopt":
+		p, err := i.ToPart()
+		return &p, err
+	case "job":
+		j, err := i.ToJob()
+		return &j, err
+	}
+	return nil, fmt.Errorf("gophernews: item %d is of type %q: %w", i.ID(), i.Type(), ErrUnknownType)
+}
+
+// ItemSwitch dispatches a TypedItem to the function for its concrete type.
+// Functions left nil are skipped.
+type ItemSwitch struct {
+	Story   func(*Story)
+	Comment func(*Comment)
+	Poll    func(*Poll)
+	Part    func(*Part)
+	Job     func(*Job)
+}
+
+// Apply calls the function matching t's concrete type and reports whether
+// one was called
+func (s ItemSwitch) Apply(t TypedItem) bool {
+	switch v := t.(type) {
+	case *Story:
+		if s.Story != nil {
+			s.Story(v)
+			return true
+		}
+	case *Comment:
+		if s.Comment != nil {
+			s.Comment(v)
+			return true
+		}
+	case *Poll:
+		if s.Poll != nil {
+			s.Poll(v)
+			return true
+		}
+	case *Part:
+		if s.Part != nil {
+			s.Part(v)
+			return true
+		}
+	case *Job:
+		if s.Job != nil {
+			s.Job(v)
+			return true
+		}
+	}
+	return false
+}
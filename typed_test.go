@@ -0,0 +1,71 @@
+package gophernews
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetAny(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Set up API stubs for one item of every type
+	for _, id := range []string{"8863", "2921983", "126809", "160705", "192327"} {
+		handleItemFixture(t, id)
+	}
+
+	var seen []string
+
+	s := ItemSwitch{
+		Story:   func(s *Story) { seen = append(seen, "story: "+s.Title) },
+		Comment: func(c *Comment) { seen = append(seen, "comment by "+c.By) },
+		Poll:    func(p *Poll) { seen = append(seen, fmt.Sprintf("poll with %d parts", len(p.Parts))) },
+		Part:    func(p *Part) { seen = append(seen, fmt.Sprintf("part of %d", p.Parent)) },
+		Job:     func(j *Job) { seen = append(seen, "job: "+j.Title) },
+	}
+
+	for _, id := range []int{8863, 2921983, 126809, 160705, 192327} {
+		v, err := client.GetAny(id)
+		if err != nil {
+			t.Errorf("Error for client.GetAny(%d) should have been nil. Was: %v", id, err)
+			continue
+		}
+		if v.ItemID() != id {
+			t.Errorf("client.GetAny(%d) returned item %d", id, v.ItemID())
+		}
+		if !s.Apply(v) {
+			t.Errorf("ItemSwitch.Apply did not handle %T", v)
+		}
+	}
+
+	expected := []string{
+		"story: My YC app: Dropbox - Throw away your USB drive",
+		"comment by norvig",
+		"poll with 3 parts",
+		"part of 160704",
+		"job: Justin.tv is looking for a Lead Flash Engineer!",
+	}
+	if fmt.Sprint(seen) != fmt.Sprint(expected) {
+		t.Errorf("ItemSwitch saw %q, was expecting %q", seen, expected)
+	}
+
+	// Makes sure functions left nil are skipped
+	if (ItemSwitch{}).Apply(&Story{}) {
+		t.Errorf("An empty ItemSwitch reported handling a *Story")
+	}
+}
+
+func TestGetAnyUnknownType(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v0/item/1.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1,"type":"advert"}`)
+	})
+
+	if v, err := client.GetAny(1); !errors.Is(err, ErrUnknownType) || v != nil {
+		t.Errorf("client.GetAny(1) returned %v, %v, was expecting nil, %v", v, err, ErrUnknownType)
+	}
+}
@@ -1,10 +1,12 @@
 package gophernews
 
+// User was first generated by gojson from json/chimeracoder.json, and is
+// now maintained by hand
 type User struct {
-	About     string `json:"about"`
-	Created   int    `json:"created"`
-	Delay     int    `json:"delay"`
-	ID        string `json:"id"`
-	Karma     int    `json:"karma"`
-	Submitted []int  `json:"submitted"`
+	About     string    `json:"about"`
+	Created   Timestamp `json:"created"`
+	Delay     int       `json:"delay"`
+	ID        string    `json:"id"`
+	Karma     int       `json:"karma"`
+	Submitted []int     `json:"submitted"`
 }
@@ -0,0 +1,343 @@
+package gophernews
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ChangeType tells what a ChangeEvent is about
+type ChangeType int
+
+const (
+	// ItemChanged is sent for an item listed in /updates when nothing more
+	// precise is known: items aren't fetched, it is the first version seen,
+	// or the change matches no other type
+	ItemChanged ChangeType = iota
+	ScoreChanged
+	NewKid
+	Deleted
+	TitleEdited
+	TextEdited
+	// ProfileChanged is the ItemChanged of profiles
+	ProfileChanged
+	ProfileKarmaChanged
+)
+
+var changeTypeNames = map[ChangeType]string{
+	ItemChanged:         "ItemChanged",
+	ScoreChanged:        "ScoreChanged",
+	NewKid:              "NewKid",
+	Deleted:             "Deleted",
+	TitleEdited:         "TitleEdited",
+	TextEdited:          "TextEdited",
+	ProfileChanged:      "ProfileChanged",
+	ProfileKarmaChanged: "ProfileKarmaChanged",
+}
+
+func (t ChangeType) String() string {
+	if name, ok := changeTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("ChangeType(%d)", int(t))
+}
+
+// ChangeEvent is a change seen by Watch
+type ChangeEvent struct {
+	Type ChangeType
+	// ItemID is set for item changes
+	ItemID int
+	// User is set for profile changes
+	User string
+	// Old and New are the versions of the item before and after the change,
+	// when items are fetched. Old is nil the first time an item is seen.
+	Old, New Item
+	// OldUser and NewUser are the versions of the profile before and after
+	// the change, when profiles are fetched. OldUser is nil the first time
+	// a profile is seen.
+	OldUser, NewUser *User
+	// Kids are the new replies of a NewKid event
+	Kids []int
+	// Err is set when the changed item or profile could not be fetched
+	Err error
+}
+
+// WatchOptions tunes Watch. A nil *WatchOptions uses the defaults.
+type WatchOptions struct {
+	// Interval is the time between two polls of /updates; 30 seconds when zero
+	Interval time.Duration
+	// FetchItems fetches every changed item and diffs it against the
+	// previous version, for typed events
+	FetchItems bool
+	// FetchProfiles does the same for profiles
+	FetchProfiles bool
+	// Concurrency is the number of requests in flight at once; 8 when zero
+	Concurrency int
+	// Remember is the number of fetched items and profiles whose last
+	// version is kept to diff against, even once they leave /updates;
+	// 10000 when zero. The least recently seen are forgotten first.
+	Remember int
+}
+
+// Watch polls /updates every Interval and sends what changed on the
+// returned channel, which is closed once ctx is done. An ID listed in two
+// polls in a row is only reported again if items are fetched and it
+// changed in between. Fetched items are diffed against the last version
+// seen, even polls ago. Fetched items bypass the Client's cache.
+func (c *Client) Watch(ctx context.Context, opts *WatchOptions) <-chan ChangeEvent {
+	if opts == nil {
+		opts = &WatchOptions{}
+	}
+
+	w := &watcher{
+		client:         c,
+		opts:           *opts,
+		events:         make(chan ChangeEvent),
+		listedItems:    make(map[int]bool),
+		listedProfiles: make(map[string]bool),
+	}
+	if w.opts.Interval <= 0 {
+		w.opts.Interval = 30 * time.Second
+	}
+	if w.opts.Remember <= 0 {
+		w.opts.Remember = 10000
+	}
+	w.seen = newRecent(w.opts.Remember)
+
+	go w.run(withoutCache(ctx))
+
+	return w.events
+}
+
+type watcher struct {
+	client *Client
+	opts   WatchOptions
+	events chan ChangeEvent
+
+	// What the previous poll listed
+	listedItems    map[int]bool
+	listedProfiles map[string]bool
+
+	// The last fetched versions of items and profiles, under their cache key
+	seen *recent
+}
+
+// recent is a map of bounded size, forgetting the least recently used
+// entries first
+type recent struct {
+	max     int
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+type recentEntry struct {
+	key   string
+	value interface{}
+}
+
+func newRecent(max int) *recent {
+	return &recent{max: max, ll: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (r *recent) get(key string) (interface{}, bool) {
+	el, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+	r.ll.MoveToFront(el)
+	return el.Value.(*recentEntry).value, true
+}
+
+func (r *recent) put(key string, value interface{}) {
+	if el, ok := r.entries[key]; ok {
+		el.Value.(*recentEntry).value = value
+		r.ll.MoveToFront(el)
+		return
+	}
+
+	r.entries[key] = r.ll.PushFront(&recentEntry{key: key, value: value})
+	for r.ll.Len() > r.max {
+		oldest := r.ll.Back()
+		r.ll.Remove(oldest)
+		delete(r.entries, oldest.Value.(*recentEntry).key)
+	}
+}
+
+func (w *watcher) run(ctx context.Context) {
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		// A failed poll is retried on the next tick
+		if changes, err := w.client.GetChangesContext(ctx); err == nil {
+			if !w.diffItems(ctx, changes.Items) || !w.diffProfiles(ctx, changes.Profiles) {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sends an event, reporting false if ctx is done first
+func (w *watcher) send(ctx context.Context, event ChangeEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case w.events <- event:
+		return true
+	}
+}
+
+// Reports the changes of the listed items and remembers them for the next polls
+func (w *watcher) diffItems(ctx context.Context, ids []int) bool {
+	listed := make(map[int]bool, len(ids))
+	defer func() { w.listedItems = listed }()
+
+	if !w.opts.FetchItems {
+		for _, id := range ids {
+			if !w.listedItems[id] {
+				if !w.send(ctx, ChangeEvent{Type: ItemChanged, ItemID: id}) {
+					return false
+				}
+			}
+			listed[id] = true
+		}
+		return true
+	}
+
+	results, err := w.client.GetItems(ctx, ids, &BatchOptions{Concurrency: w.opts.Concurrency})
+	if err != nil {
+		return false
+	}
+
+	for _, r := range results {
+		listed[r.ID] = true
+		if r.Err != nil {
+			if !w.send(ctx, ChangeEvent{Type: ItemChanged, ItemID: r.ID, Err: r.Err}) {
+				return false
+			}
+			continue
+		}
+
+		key := "item/" + strconv.Itoa(r.ID)
+		var old Item
+		if v, ok := w.seen.get(key); ok {
+			old = v.(Item)
+		}
+		w.seen.put(key, r.Item)
+
+		for _, event := range itemChanges(old, r.Item) {
+			if !w.send(ctx, event) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Reports the changes of the listed profiles and remembers them for the next polls
+func (w *watcher) diffProfiles(ctx context.Context, names []string) bool {
+	listed := make(map[string]bool, len(names))
+	defer func() { w.listedProfiles = listed }()
+
+	for _, name := range names {
+		listed[name] = true
+
+		if !w.opts.FetchProfiles {
+			if !w.listedProfiles[name] && !w.send(ctx, ChangeEvent{Type: ProfileChanged, User: name}) {
+				return false
+			}
+			continue
+		}
+
+		u, err := w.client.GetUserContext(ctx, name)
+		if err != nil {
+			if ctx.Err() != nil || !w.send(ctx, ChangeEvent{Type: ProfileChanged, User: name, Err: err}) {
+				return false
+			}
+			continue
+		}
+
+		key := "user/" + name
+		var old *User
+		if v, ok := w.seen.get(key); ok {
+			old = v.(*User)
+		}
+		w.seen.put(key, &u)
+
+		if old == nil || !reflect.DeepEqual(*old, u) {
+			event := ChangeEvent{Type: ProfileChanged, User: name, OldUser: old, NewUser: &u}
+			if old != nil && old.Karma != u.Karma {
+				event.Type = ProfileKarmaChanged
+			}
+			if !w.send(ctx, event) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Returns the events telling how an item changed from old to new. old is
+// nil when the item wasn't seen before.
+func itemChanges(old, new Item) []ChangeEvent {
+	event := func(t ChangeType) ChangeEvent {
+		return ChangeEvent{Type: t, ItemID: new.ID(), Old: old, New: new}
+	}
+
+	if old == nil {
+		return []ChangeEvent{event(ItemChanged)}
+	}
+
+	var events []ChangeEvent
+
+	if new.Deleted() && !old.Deleted() {
+		events = append(events, event(Deleted))
+	}
+
+	if new.Score() != old.Score() {
+		events = append(events, event(ScoreChanged))
+	}
+
+	known := make(map[int]bool)
+	for _, kid := range old.Kids() {
+		known[kid] = true
+	}
+	var kids []int
+	for _, kid := range new.Kids() {
+		if !known[kid] {
+			kids = append(kids, kid)
+		}
+	}
+	if len(kids) > 0 {
+		e := event(NewKid)
+		e.Kids = kids
+		events = append(events, e)
+	}
+
+	if new.Title() != old.Title() {
+		events = append(events, event(TitleEdited))
+	}
+
+	if new.Text() != old.Text() && !new.Deleted() {
+		events = append(events, event(TextEdited))
+	}
+
+	if len(events) == 0 && !reflect.DeepEqual(old, new) {
+		events = append(events, event(ItemChanged))
+	}
+
+	return events
+}
@@ -0,0 +1,220 @@
+package gophernews
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A fake API whose items and profiles tests change between polls
+type watchStub struct {
+	mu       sync.Mutex
+	updates  string
+	items    map[int]string
+	profiles map[string]string
+}
+
+func (s *watchStub) set(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+}
+
+func (s *watchStub) register() {
+	mux.HandleFunc("/v0/updates.json", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		fmt.Fprint(w, s.updates)
+	})
+	mux.HandleFunc("/v0/item/", func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		fmt.Sscanf(r.URL.Path, "/v0/item/%d.json", &id)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		fmt.Fprint(w, s.items[id])
+	})
+	mux.HandleFunc("/v0/user/", func(w http.ResponseWriter, r *http.Request) {
+		var name string
+		fmt.Sscanf(r.URL.Path, "/v0/user/%s", &name)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		fmt.Fprint(w, s.profiles[name[:len(name)-len(".json")]])
+	})
+}
+
+// Receives the next event, failing the test if none comes within a second
+func nextEvent(t *testing.T, events <-chan ChangeEvent) ChangeEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatalf("No event within a second")
+		return ChangeEvent{}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	stub := &watchStub{
+		updates: `{"items":[8863],"profiles":["pg"]}`,
+		items: map[int]string{
+			8863: `{"id":8863,"kids":[8952],"score":111,"title":"My YC app: Dropbox","type":"story"}`,
+		},
+		profiles: map[string]string{"pg": `{"id":"pg","karma":155040}`},
+	}
+	stub.register()
+
+	// Items are cached, but Watch must see through the cache
+	client.Cache = NewMemoryCache(100, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := client.Watch(ctx, &WatchOptions{Interval: 10 * time.Millisecond, FetchItems: true, FetchProfiles: true})
+
+	// The first versions seen are reported as they are
+	if e := nextEvent(t, events); e.Type != ItemChanged || e.ItemID != 8863 || e.Old != nil || e.New.Score() != 111 {
+		t.Errorf("First event was %+v, was expecting ItemChanged for 8863", e)
+	}
+	if e := nextEvent(t, events); e.Type != ProfileChanged || e.User != "pg" || e.NewUser.Karma != 155040 {
+		t.Errorf("Second event was %+v, was expecting ProfileChanged for pg", e)
+	}
+
+	stub.set(func() {
+		stub.items[8863] = `{"id":8863,"kids":[8952,9224],"score":112,"title":"My YC app: Dropbox - Throw away your USB drive","type":"story"}`
+		stub.profiles["pg"] = `{"id":"pg","karma":155041}`
+	})
+
+	// Makes sure each change is reported with its own type. The profile may
+	// be polled before the item has changed, so the order isn't fixed.
+	got := make(map[ChangeType]ChangeEvent)
+	for n := 0; n < 4; n++ {
+		e := nextEvent(t, events)
+		got[e.Type] = e
+	}
+
+	for _, typ := range []ChangeType{ScoreChanged, NewKid, TitleEdited, ProfileKarmaChanged} {
+		if _, ok := got[typ]; !ok {
+			t.Errorf("Got %v, was expecting a %v event", got, typ)
+		}
+	}
+	if e := got[NewKid]; fmt.Sprint(e.Kids) != "[9224]" {
+		t.Errorf("NewKid event has kids %v, was expecting [9224]", e.Kids)
+	}
+	if e := got[ScoreChanged]; e.Old == nil || e.Old.Score() != 111 || e.New.Score() != 112 {
+		t.Errorf("ScoreChanged event was %+v, was expecting 111 to 112", e)
+	}
+	if e := got[ProfileKarmaChanged]; e.OldUser == nil || e.OldUser.Karma != 155040 || e.NewUser.Karma != 155041 {
+		t.Errorf("ProfileKarmaChanged event was %+v, was expecting 155040 to 155041", e)
+	}
+
+	stub.set(func() {
+		stub.items[8863] = `{"deleted":true,"id":8863,"kids":[8952,9224],"score":112,"title":"My YC app: Dropbox - Throw away your USB drive","type":"story"}`
+	})
+
+	if e := nextEvent(t, events); e.Type != Deleted {
+		t.Errorf("Got %+v, was expecting a Deleted event", e)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchWithoutFetching(t *testing.T) {
+	setup()
+	defer teardown()
+
+	stub := &watchStub{updates: `{"items":[1,2],"profiles":["pg"]}`}
+	stub.register()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := client.Watch(ctx, &WatchOptions{Interval: 10 * time.Millisecond})
+
+	for _, expected := range []string{"item 1", "item 2", "user pg"} {
+		if e := nextEvent(t, events); fmt.Sprintf("item %d", e.ItemID) != expected && "user "+e.User != expected {
+			t.Errorf("Got %+v, was expecting an event for %s", e, expected)
+		}
+	}
+
+	// Makes sure IDs still listed aren't reported twice
+	stub.set(func() { stub.updates = `{"items":[2,3],"profiles":["pg"]}` })
+
+	if e := nextEvent(t, events); e.Type != ItemChanged || e.ItemID != 3 {
+		t.Errorf("Got %+v, was expecting ItemChanged for 3 only", e)
+	}
+
+	cancel()
+	for e := range events {
+		if e.ItemID == 2 || e.User != "" {
+			t.Errorf("Got %+v again", e)
+		}
+	}
+}
+
+func TestWatchRemembersAcrossPolls(t *testing.T) {
+	setup()
+	defer teardown()
+
+	stub := &watchStub{
+		updates:  `{"items":[1],"profiles":["pg"]}`,
+		items:    map[int]string{1: `{"id":1,"score":10,"type":"story"}`},
+		profiles: map[string]string{"pg": `{"id":"pg","karma":100}`},
+	}
+	stub.register()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := client.Watch(ctx, &WatchOptions{Interval: 10 * time.Millisecond, FetchItems: true, FetchProfiles: true})
+
+	nextEvent(t, events)
+	nextEvent(t, events)
+
+	// Both leave /updates for a while, then come back changed
+	stub.set(func() { stub.updates = `{"items":[],"profiles":[]}` })
+	time.Sleep(50 * time.Millisecond)
+	stub.set(func() {
+		stub.items[1] = `{"id":1,"score":11,"type":"story"}`
+		stub.profiles["pg"] = `{"id":"pg","karma":101}`
+		stub.updates = `{"items":[1],"profiles":["pg"]}`
+	})
+
+	got := make(map[ChangeType]ChangeEvent)
+	for n := 0; n < 2; n++ {
+		e := nextEvent(t, events)
+		got[e.Type] = e
+	}
+
+	if e, ok := got[ScoreChanged]; !ok || e.Old == nil || e.Old.Score() != 10 || e.New.Score() != 11 {
+		t.Errorf("Got %v, was expecting ScoreChanged from 10 to 11", got)
+	}
+	if e, ok := got[ProfileKarmaChanged]; !ok || e.OldUser == nil || e.OldUser.Karma != 100 {
+		t.Errorf("Got %v, was expecting ProfileKarmaChanged from 100 to 101", got)
+	}
+}
+
+func TestRecent(t *testing.T) {
+	r := newRecent(2)
+	r.put("a", 1)
+	r.put("b", 2)
+	r.get("a")
+	r.put("c", 3)
+
+	if _, ok := r.get("b"); ok {
+		t.Errorf("b should have been forgotten as the least recently used")
+	}
+	if v, ok := r.get("a"); !ok || v != 1 {
+		t.Errorf("get(\"a\") returned %v, %v, was expecting 1", v, ok)
+	}
+	if v, ok := r.get("c"); !ok || v != 3 {
+		t.Errorf("get(\"c\") returned %v, %v, was expecting 3", v, ok)
+	}
+}